@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+var (
+	alertWebhook string
+	slackWebhook string
+)
+
+// alertEvent is the webhook payload for one threshold changing state.
+type alertEvent struct {
+	Status    string    `json:"status"` // "firing" or "resolved"
+	Metric    string    `json:"metric"`
+	Value     *float64  `json:"value,omitempty"`
+	Threshold float64   `json:"threshold"`
+	Flag      string    `json:"flag"`
+	Host      string    `json:"host"`
+	Timestamp time.Time `json:"ts"`
+}
+
+// alertSink POSTs to a webhook when a --max-* threshold is crossed and
+// again when the metric recovers, but not on every sample in between.
+// payload shapes the request body for the receiving service.
+type alertSink struct {
+	sinkName string
+	url      string
+	payload  func(alertEvent) any
+	client   *http.Client
+	firing   map[string]breach // by metric
+}
+
+func newAlertSink(name, url string, payload func(alertEvent) any) *alertSink {
+	return &alertSink{
+		sinkName: name,
+		url:      url,
+		payload:  payload,
+		client:   &http.Client{Timeout: pushTimeout},
+		firing:   map[string]breach{},
+	}
+}
+
+func openAlert(context.Context) (sink, error) {
+	if alertWebhook == "" {
+		return nil, nil
+	}
+	return newAlertSink("alert-webhook", alertWebhook, func(ev alertEvent) any { return ev }), nil
+}
+
+// openSlack posts the same events as Slack incoming-webhook messages.
+func openSlack(context.Context) (sink, error) {
+	if slackWebhook == "" {
+		return nil, nil
+	}
+	return newAlertSink("slack", slackWebhook, slackMessage), nil
+}
+
+func slackMessage(ev alertEvent) any {
+	value := "n/a"
+	if ev.Value != nil {
+		value = fmtFloat(*ev.Value, 1)
+	}
+	text := fmt.Sprintf(":rotating_light: *%s*: `%s` is %s, over the --%s limit of %g", ev.Host, ev.Metric, value, ev.Flag, ev.Threshold)
+	if ev.Status == "resolved" {
+		text = fmt.Sprintf(":white_check_mark: *%s*: `%s` is back to %s, under the --%s limit of %g", ev.Host, ev.Metric, value, ev.Flag, ev.Threshold)
+	}
+	return map[string]string{"text": text}
+}
+
+func (a *alertSink) name() string { return a.sinkName }
+
+// write compares this sample's breaches with those already firing and
+// sends one event per change. An event that fails to send leaves that
+// metric's state alone, so it is retried on the next sample. All of a
+// sample's events share one pushDeadline, so a dead webhook holds up the
+// sampling loop no longer than a failing --push-url does.
+func (a *alertSink) write(ctx context.Context, snap Snapshot) error {
+	ctx, cancel := context.WithTimeout(ctx, pushDeadline)
+	defer cancel()
+	now := map[string]breach{}
+	for _, b := range checkThresholds(snap) {
+		now[b.Metric] = b
+	}
+	current := map[string]float64{}
+	for _, mv := range metricValues(snap) {
+		current[mv.Name] = mv.Value
+	}
+
+	var errs []error
+	send := func(status string, b breach) bool {
+		ev := alertEvent{Status: status, Metric: b.Metric, Threshold: b.Limit, Flag: b.Flag, Host: snap.Host, Timestamp: snap.Timestamp}
+		if v, ok := current[b.Metric]; ok {
+			ev.Value = &v
+		}
+		body, err := json.Marshal(a.payload(ev))
+		if err == nil {
+			_, err = postJSON(ctx, a.client, a.url, nil, body)
+		}
+		if err != nil {
+			errs = append(errs, err)
+			return false
+		}
+		return true
+	}
+	for m, b := range now {
+		if _, ok := a.firing[m]; !ok && send("firing", b) {
+			a.firing[m] = b
+		}
+	}
+	for m, b := range a.firing {
+		if _, ok := now[m]; !ok && send("resolved", b) {
+			delete(a.firing, m)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (a *alertSink) close() error { return nil }
+
+func init() {
+	registerSink(openAlert)
+	registerSink(openSlack)
+	collectCmd.Flags().StringVar(&alertWebhook, "alert-webhook", "", "POST a JSON alert to this URL when a --max-* threshold is crossed and when it recovers")
+	collectCmd.Flags().StringVar(&slackWebhook, "slack-webhook", "", "post threshold alerts and recoveries to this Slack incoming-webhook URL")
+}
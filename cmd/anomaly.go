@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+var (
+	detectAnomalies bool
+	anomalyWarmup   int
+	anomalyZ        float64
+)
+
+// Anomaly is one metric that was unusually far from its running mean.
+type Anomaly struct {
+	Metric string  `json:"metric"`
+	Value  float64 `json:"value"`
+	Mean   float64 `json:"mean"`
+	ZScore float64 `json:"z_score"`
+}
+
+// runningStat is Welford's online mean and variance, so the baseline costs
+// the same few words of memory however long the stream runs.
+type runningStat struct {
+	n    int
+	mean float64
+	m2   float64
+}
+
+func (r *runningStat) add(v float64) {
+	r.n++
+	d := v - r.mean
+	r.mean += d / float64(r.n)
+	r.m2 += d * (v - r.mean)
+}
+
+func (r *runningStat) stddev() float64 {
+	if r.n < 2 {
+		return 0
+	}
+	return math.Sqrt(r.m2 / float64(r.n-1))
+}
+
+// anomalyDetector flags metrics more than --anomaly-z standard deviations
+// from their mean so far. A nil *anomalyDetector ignores everything, like
+// a nil *smoother.
+type anomalyDetector struct {
+	stats map[string]*runningStat
+}
+
+func newAnomalyDetector() *anomalyDetector {
+	if !detectAnomalies {
+		return nil
+	}
+	return &anomalyDetector{stats: map[string]*runningStat{}}
+}
+
+// apply checks each headline metric of s against its baseline, then folds
+// the reading into it. A metric isn't judged until it has --anomaly-warmup
+// readings, nor while it has never varied (a z-score needs a spread).
+func (d *anomalyDetector) apply(s *Snapshot) {
+	if d == nil {
+		return
+	}
+	for _, mv := range metricValues(*s) {
+		st, ok := d.stats[mv.Name]
+		if !ok {
+			st = &runningStat{}
+			d.stats[mv.Name] = st
+		}
+		if sd := st.stddev(); st.n >= anomalyWarmup && sd > 0 {
+			if z := (mv.Value - st.mean) / sd; math.Abs(z) > anomalyZ {
+				s.Anomalies = append(s.Anomalies, Anomaly{Metric: mv.Name, Value: mv.Value, Mean: st.mean, ZScore: z})
+			}
+		}
+		st.add(mv.Value)
+	}
+	s.Anomaly = len(s.Anomalies) > 0
+}
+
+func validateAnomalyFlags() error {
+	if anomalyWarmup < 2 {
+		return fmt.Errorf("--anomaly-warmup must be at least 2, got %d", anomalyWarmup)
+	}
+	if anomalyZ <= 0 {
+		return fmt.Errorf("--anomaly-z must be positive, got %g", anomalyZ)
+	}
+	return nil
+}
+
+// humanAnomalies renders the ANOMALY column as the offending metric names.
+func (s Snapshot) humanAnomalies() string {
+	if !s.Anomaly {
+		return "-"
+	}
+	names := make([]string, len(s.Anomalies))
+	for i, a := range s.Anomalies {
+		names[i] = a.Metric
+	}
+	return strings.Join(names, ",")
+}
+
+func init() {
+	collectCmd.Flags().BoolVar(&detectAnomalies, "detect-anomalies", false, "mark samples where a metric is more than --anomaly-z standard deviations from its running mean (streaming only)")
+	collectCmd.Flags().IntVar(&anomalyWarmup, "anomaly-warmup", 30, "readings of a metric to learn from before --detect-anomalies judges it")
+	collectCmd.Flags().Float64Var(&anomalyZ, "anomaly-z", 3, "z-score beyond which --detect-anomalies flags a metric")
+}
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"math"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	baselineFile     string
+	baselineOut      string
+	baselineDuration time.Duration
+	baselineInterval time.Duration
+
+	// loadedBaseline is --baseline, read once before the run starts
+	loadedBaseline *Baseline
+)
+
+// baselineVersion is bumped whenever the file layout changes incompatibly.
+const baselineVersion = 1
+
+// Baseline is the on-disk summary of a known-good period: the mean and
+// standard deviation of every headline metric, named as in metricValues.
+type Baseline struct {
+	Version    int                     `json:"version"`
+	Host       string                  `json:"host"`
+	CapturedAt time.Time               `json:"captured_at"`
+	Duration   string                  `json:"duration"`
+	Samples    int                     `json:"samples"`
+	Metrics    map[string]BaselineStat `json:"metrics"`
+}
+
+// BaselineStat summarizes one metric over the capture.
+type BaselineStat struct {
+	N      int     `json:"n"`
+	Mean   float64 `json:"mean"`
+	Stddev float64 `json:"stddev"`
+}
+
+func readBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if b.Version != baselineVersion {
+		return nil, fmt.Errorf("%s: unsupported baseline version %d (want %d)", path, b.Version, baselineVersion)
+	}
+	return &b, nil
+}
+
+// resolveBaseline loads --baseline, if given.
+func resolveBaseline() error {
+	loadedBaseline = nil
+	if baselineFile == "" {
+		return nil
+	}
+	b, err := readBaseline(baselineFile)
+	if err != nil {
+		return err
+	}
+	loadedBaseline = b
+	return nil
+}
+
+// applyBaseline annotates s with how many standard deviations each metric
+// sits from the --baseline mean, signed so +2 is above it. Metrics the
+// baseline doesn't have, or that never varied during the capture, are left
+// out.
+func (s *Snapshot) applyBaseline() {
+	if loadedBaseline == nil {
+		return
+	}
+	for _, mv := range metricValues(*s) {
+		st, ok := loadedBaseline.Metrics[mv.Name]
+		if !ok || st.Stddev == 0 {
+			continue
+		}
+		if s.BaselineSigma == nil {
+			s.BaselineSigma = map[string]float64{}
+		}
+		s.BaselineSigma[mv.Name] = (mv.Value - st.Mean) / st.Stddev
+	}
+}
+
+// humanBaseline renders the BASELINE column as the metric furthest from
+// the baseline, e.g. "cpu_percent=+3.2sd".
+func (s Snapshot) humanBaseline() string {
+	if len(s.BaselineSigma) == 0 {
+		return "-"
+	}
+	worst, dev := "", 0.0
+	for _, name := range slices.Sorted(maps.Keys(s.BaselineSigma)) {
+		if d := s.BaselineSigma[name]; worst == "" || math.Abs(d) > math.Abs(dev) {
+			worst, dev = name, d
+		}
+	}
+	return fmt.Sprintf("%s=%+.1fsd", worst, dev)
+}
+
+var baselineCmd = &cobra.Command{
+	Use:   "baseline",
+	Short: "Capture a known-good baseline to compare live samples against",
+}
+
+var baselineCaptureCmd = &cobra.Command{
+	Use:   "capture",
+	Short: "Sample for a while and save per-metric mean and stddev",
+	Long: `capture samples every --interval for --duration and writes the mean and
+standard deviation of each metric to --out. Pass the file to
+collect --baseline to see how far live samples deviate from it. Ctrl-c ends
+the capture early and still saves what was recorded.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if baselineInterval <= 0 || baselineDuration < baselineInterval {
+			return fmt.Errorf("--interval must be positive and no longer than --duration")
+		}
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+
+		start := time.Now()
+		stats := map[string]*runningStat{}
+		var host string
+		samples := 0
+		t := time.NewTicker(baselineInterval)
+		defer t.Stop()
+		deadline := time.After(baselineDuration)
+		var prev *Snapshot
+	loop:
+		for {
+			snap, err := collectOnce(ctx)
+			if ctx.Err() != nil {
+				break
+			}
+			if err != nil {
+				slog.Warn("collection failed; skipping this sample", "err", err)
+			} else {
+				snap.applyRates(prev)
+				prev = &snap
+				host = snap.Host
+				samples++
+				for _, mv := range metricValues(snap) {
+					if stats[mv.Name] == nil {
+						stats[mv.Name] = &runningStat{}
+					}
+					stats[mv.Name].add(mv.Value)
+				}
+			}
+			select {
+			case <-ctx.Done():
+				break loop
+			case <-deadline:
+				break loop
+			case <-t.C:
+			}
+		}
+		if samples == 0 {
+			return fmt.Errorf("no samples collected")
+		}
+
+		b := Baseline{
+			Version:    baselineVersion,
+			Host:       host,
+			CapturedAt: start.UTC(),
+			Duration:   time.Since(start).Round(time.Second).String(),
+			Samples:    samples,
+			Metrics:    make(map[string]BaselineStat, len(stats)),
+		}
+		for name, st := range stats {
+			b.Metrics[name] = BaselineStat{N: st.n, Mean: st.mean, Stddev: st.stddev()}
+		}
+		data, err := json.MarshalIndent(b, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(baselineOut, append(data, '\n'), 0o644); err != nil {
+			return err
+		}
+		slog.Info("baseline saved", "file", baselineOut, "samples", samples, "metrics", len(b.Metrics))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(baselineCmd)
+	baselineCmd.AddCommand(baselineCaptureCmd)
+	baselineCaptureCmd.Flags().DurationVar(&baselineDuration, "duration", 5*time.Minute, "how long to sample for")
+	baselineCaptureCmd.Flags().DurationVar(&baselineInterval, "interval", 5*time.Second, "sampling interval")
+	baselineCaptureCmd.Flags().StringVar(&baselineOut, "out", "baseline.json", "file to write the baseline to")
+	collectCmd.Flags().StringVar(&baselineFile, "baseline", "", "annotate each sample with how many standard deviations its metrics are from this baseline file (see baseline capture)")
+}
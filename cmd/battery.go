@@ -0,0 +1,22 @@
+package cmd
+
+import "fmt"
+
+var battery bool
+
+// humanBattery renders the BATT column as "87%+" while charging, "87%"
+// otherwise.
+func (s Snapshot) humanBattery() string {
+	if s.BatteryPercent == nil {
+		return "-"
+	}
+	cell := fmt.Sprintf("%.0f%%", *s.BatteryPercent)
+	if s.BatteryCharging != nil && *s.BatteryCharging {
+		cell += "+"
+	}
+	return cell
+}
+
+func init() {
+	collectCmd.Flags().BoolVar(&battery, "battery", false, "report battery charge and charging state (Linux; omitted without a battery)")
+}
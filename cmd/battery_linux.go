@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// readBattery averages the capacity of every battery under
+// /sys/class/power_supply; it reports charging if any battery is. Machines
+// without one get errUnsupported, which collectOnce treats as nothing to
+// report rather than a failure, so the fields are left out.
+func readBattery() (pct float64, charging bool, err error) {
+	dirs, _ := filepath.Glob("/sys/class/power_supply/*")
+	var sum float64
+	n := 0
+	for _, dir := range dirs {
+		if t, _ := readSysFile(dir, "type"); t != "Battery" {
+			continue
+		}
+		c, err := readSysFile(dir, "capacity")
+		if err != nil {
+			continue
+		}
+		v, err := strconv.ParseFloat(c, 64)
+		if err != nil {
+			continue
+		}
+		sum += v
+		n++
+		if status, _ := readSysFile(dir, "status"); strings.EqualFold(status, "Charging") {
+			charging = true
+		}
+	}
+	if n == 0 {
+		return 0, false, errUnsupported
+	}
+	return sum / float64(n), charging, nil
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package cmd
+
+// readBattery reports nothing: battery readings are Linux-only.
+func readBattery() (pct float64, charging bool, err error) {
+	return 0, false, errUnsupported
+}
@@ -0,0 +1,47 @@
+package cmd
+
+var cgroupStats bool
+
+// CgroupStat reports usage against the limits of the cgroup gostats runs
+// in, which is what matters inside a container: host-wide memory and CPU
+// figures describe the node, not the container. Nil limits mean unlimited.
+type CgroupStat struct {
+	Version int `json:"version"`
+
+	MemLimitMB *uint64  `json:"mem_limit_mb,omitempty"`
+	MemUsedMB  uint64   `json:"mem_used_mb"`
+	MemUsedPct *float64 `json:"mem_used_pct,omitempty"`
+
+	CPULimitCores *float64 `json:"cpu_limit_cores,omitempty"`
+	// CPUPercent is usage relative to the CPU limit (or to one core when
+	// unlimited), averaged since the previous sample.
+	CPUPercent *float64 `json:"cpu_percent,omitempty"`
+
+	cpuUsageNs uint64
+}
+
+// applyCgroupRate fills CPUPercent from the usage counters of two samples.
+func (c *CgroupStat) applyCgroupRate(prev *CgroupStat, elapsed float64) {
+	if prev == nil || c.cpuUsageNs < prev.cpuUsageNs {
+		return
+	}
+	cores := 1.0
+	if c.CPULimitCores != nil {
+		cores = *c.CPULimitCores
+	}
+	used := float64(c.cpuUsageNs-prev.cpuUsageNs) / 1e9 / elapsed
+	c.CPUPercent = ptr(used / cores * 100)
+}
+
+// humanColumns renders the CG_MEM% and CG_CPU% cells; c may be nil when the
+// cgroup couldn't be read.
+func (c *CgroupStat) humanColumns() string {
+	if c == nil {
+		return "-\t-"
+	}
+	return optFloat(c.MemUsedPct, 1) + "\t" + optFloat(c.CPUPercent, 1)
+}
+
+func init() {
+	collectCmd.Flags().BoolVar(&cgroupStats, "cgroup", false, "report memory and CPU usage against this process's cgroup limits (Linux containers)")
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const cgroupRoot = "/sys/fs/cgroup"
+
+// cgroupDir finds the directory for controller (ignored on v2) by joining
+// the path from /proc/self/cgroup onto the mount, falling back to the mount
+// itself when the namespace already hides the hierarchy.
+func cgroupDir(v2 bool, controller string) string {
+	base := cgroupRoot
+	if !v2 {
+		base = filepath.Join(cgroupRoot, controller)
+	}
+	f, err := os.Open("/proc/self/cgroup")
+	if err != nil {
+		return base
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		// hierarchy-ID:controller-list:path
+		parts := strings.SplitN(sc.Text(), ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		match := v2 && parts[0] == "0" && parts[1] == ""
+		if !v2 {
+			for _, c := range strings.Split(parts[1], ",") {
+				match = match || c == controller
+			}
+		}
+		if match {
+			if dir := filepath.Join(base, parts[2]); dirExists(dir) {
+				return dir
+			}
+		}
+	}
+	return base
+}
+
+func dirExists(p string) bool {
+	fi, err := os.Stat(p)
+	return err == nil && fi.IsDir()
+}
+
+// readSysFile reads a single-value sysfs/cgroupfs file, trimmed.
+func readSysFile(dir, name string) (string, error) {
+	b, err := os.ReadFile(filepath.Join(dir, name))
+	return strings.TrimSpace(string(b)), err
+}
+
+func readSysUint(dir, name string) (uint64, error) {
+	s, err := readSysFile(dir, name)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
+
+// v1 reports "no limit" as a huge page-aligned number rather than a marker.
+const cgroupV1Unlimited = 1 << 62
+
+func readCgroup() (*CgroupStat, error) {
+	v2 := fileExists(filepath.Join(cgroupRoot, "cgroup.controllers"))
+	if v2 {
+		return readCgroupV2()
+	}
+	return readCgroupV1()
+}
+
+func fileExists(p string) bool {
+	_, err := os.Stat(p)
+	return err == nil
+}
+
+func readCgroupV2() (*CgroupStat, error) {
+	dir := cgroupDir(true, "")
+	c := &CgroupStat{Version: 2}
+
+	used, err := readSysUint(dir, "memory.current")
+	if err != nil {
+		return nil, err
+	}
+	c.MemUsedMB = used / (1024 * 1024)
+	if s, err := readSysFile(dir, "memory.max"); err == nil && s != "max" {
+		if limit, err := strconv.ParseUint(s, 10, 64); err == nil && limit > 0 {
+			c.setMemLimit(used, limit)
+		}
+	}
+
+	// cpu.max is "quota period" or "max period"
+	if s, err := readSysFile(dir, "cpu.max"); err == nil {
+		var quota string
+		var period float64
+		if _, err := fmt.Sscan(s, &quota, &period); err == nil && quota != "max" && period > 0 {
+			if q, err := strconv.ParseFloat(quota, 64); err == nil {
+				c.CPULimitCores = ptr(q / period)
+			}
+		}
+	}
+	if s, err := readSysFile(dir, "cpu.stat"); err == nil {
+		for _, line := range strings.Split(s, "\n") {
+			if v, ok := strings.CutPrefix(line, "usage_usec "); ok {
+				if us, err := strconv.ParseUint(v, 10, 64); err == nil {
+					c.cpuUsageNs = us * 1000
+				}
+			}
+		}
+	}
+	return c, nil
+}
+
+func readCgroupV1() (*CgroupStat, error) {
+	mdir := cgroupDir(false, "memory")
+	c := &CgroupStat{Version: 1}
+
+	used, err := readSysUint(mdir, "memory.usage_in_bytes")
+	if err != nil {
+		return nil, err
+	}
+	c.MemUsedMB = used / (1024 * 1024)
+	if limit, err := readSysUint(mdir, "memory.limit_in_bytes"); err == nil && limit < cgroupV1Unlimited {
+		c.setMemLimit(used, limit)
+	}
+
+	cdir := cgroupDir(false, "cpu")
+	if quota, err := readSysFile(cdir, "cpu.cfs_quota_us"); err == nil && quota != "-1" {
+		q, qerr := strconv.ParseFloat(quota, 64)
+		period, perr := readSysUint(cdir, "cpu.cfs_period_us")
+		if qerr == nil && perr == nil && period > 0 {
+			c.CPULimitCores = ptr(q / float64(period))
+		}
+	}
+	if ns, err := readSysUint(cgroupDir(false, "cpuacct"), "cpuacct.usage"); err == nil {
+		c.cpuUsageNs = ns
+	}
+	return c, nil
+}
+
+func (c *CgroupStat) setMemLimit(used, limit uint64) {
+	c.MemLimitMB = ptr(limit / (1024 * 1024))
+	c.MemUsedPct = ptr(float64(used) / float64(limit) * 100)
+}
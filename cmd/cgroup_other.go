@@ -0,0 +1,7 @@
+//go:build !linux
+
+package cmd
+
+func readCgroup() (*CgroupStat, error) {
+	return nil, errUnsupported
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+var cloud bool
+
+// CloudMeta identifies the cloud instance gostats runs on.
+type CloudMeta struct {
+	Provider     string `json:"provider"`
+	InstanceID   string `json:"instance_id"`
+	Region       string `json:"region,omitempty"`
+	Zone         string `json:"zone,omitempty"`
+	InstanceType string `json:"instance_type,omitempty"`
+}
+
+// The link-local metadata service all three providers answer on.
+const metadataHost = "http://169.254.169.254"
+
+// cloudTimeout bounds detection; off-cloud the address doesn't answer at
+// all and we don't want to hold up the start of the run for long.
+const cloudTimeout = time.Second
+
+// metadataClient never goes through HTTP(S)_PROXY: the metadata service is
+// link-local and a proxy would answer for the wrong machine, or not at all.
+var metadataClient = &http.Client{Transport: &http.Transport{Proxy: nil}}
+
+// cloudInfo is what resolveCloud found; nil means not on a (recognised)
+// cloud instance.
+var cloudInfo *CloudMeta
+
+// resolveCloud detects the provider for --cloud before the first sample,
+// under its own cloudTimeout, so a short --collect-timeout or a slow first
+// cycle can't cut detection short and leave it off for the whole run. With
+// --ssh the remote gostats detects its own.
+func resolveCloud(ctx context.Context) {
+	cloudInfo = nil
+	if !cloud || remote != nil {
+		return
+	}
+	cloudInfo = detectCloud(ctx)
+	if cloudInfo == nil {
+		slog.Debug("no cloud metadata service answered; --cloud has nothing to add")
+	}
+}
+
+// detectCloud asks every provider's endpoint at once; only the right one
+// accepts its provider-specific headers.
+func detectCloud(ctx context.Context) *CloudMeta {
+	ctx, cancel := context.WithTimeout(ctx, cloudTimeout)
+	defer cancel()
+
+	probes := []func(context.Context) (*CloudMeta, error){awsMeta, gcpMeta, azureMeta}
+	found := make(chan *CloudMeta, len(probes))
+	for _, probe := range probes {
+		go func() {
+			m, err := probe(ctx)
+			if err != nil {
+				m = nil
+			}
+			found <- m
+		}()
+	}
+	for range probes {
+		if m := <-found; m != nil {
+			return m
+		}
+	}
+	return nil
+}
+
+// metadataGet fetches url with the given headers and decodes a JSON body
+// into v (or a plain-text body into a *string).
+func metadataGet(ctx context.Context, method, url string, headers map[string]string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return err
+	}
+	for k, val := range headers {
+		req.Header.Set(k, val)
+	}
+	resp, err := metadataClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s: %s", method, url, resp.Status)
+	}
+	if s, ok := v.(*string); ok {
+		b, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		*s = strings.TrimSpace(string(b))
+		return err
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// awsMeta uses IMDSv2, which needs a session token first.
+func awsMeta(ctx context.Context) (*CloudMeta, error) {
+	var token string
+	if err := metadataGet(ctx, http.MethodPut, metadataHost+"/latest/api/token",
+		map[string]string{"X-aws-ec2-metadata-token-ttl-seconds": "60"}, &token); err != nil {
+		return nil, err
+	}
+	var doc struct {
+		InstanceID       string `json:"instanceId"`
+		Region           string `json:"region"`
+		AvailabilityZone string `json:"availabilityZone"`
+		InstanceType     string `json:"instanceType"`
+	}
+	if err := metadataGet(ctx, http.MethodGet, metadataHost+"/latest/dynamic/instance-identity/document",
+		map[string]string{"X-aws-ec2-metadata-token": token}, &doc); err != nil {
+		return nil, err
+	}
+	return &CloudMeta{Provider: "aws", InstanceID: doc.InstanceID, Region: doc.Region,
+		Zone: doc.AvailabilityZone, InstanceType: doc.InstanceType}, nil
+}
+
+func gcpMeta(ctx context.Context) (*CloudMeta, error) {
+	var inst struct {
+		ID          json.Number `json:"id"`
+		Zone        string      `json:"zone"`        // projects/N/zones/us-central1-a
+		MachineType string      `json:"machineType"` // projects/N/machineTypes/e2-medium
+	}
+	if err := metadataGet(ctx, http.MethodGet, metadataHost+"/computeMetadata/v1/instance/?recursive=true",
+		map[string]string{"Metadata-Flavor": "Google"}, &inst); err != nil {
+		return nil, err
+	}
+	zone := path.Base(inst.Zone)
+	region := zone
+	if i := strings.LastIndex(zone, "-"); i > 0 {
+		region = zone[:i]
+	}
+	return &CloudMeta{Provider: "gcp", InstanceID: inst.ID.String(), Region: region,
+		Zone: zone, InstanceType: path.Base(inst.MachineType)}, nil
+}
+
+func azureMeta(ctx context.Context) (*CloudMeta, error) {
+	var inst struct {
+		Compute struct {
+			VMID     string `json:"vmId"`
+			Location string `json:"location"`
+			Zone     string `json:"zone"`
+			VMSize   string `json:"vmSize"`
+		} `json:"compute"`
+	}
+	if err := metadataGet(ctx, http.MethodGet, metadataHost+"/metadata/instance?api-version=2021-02-01",
+		map[string]string{"Metadata": "true"}, &inst); err != nil {
+		return nil, err
+	}
+	c := inst.Compute
+	return &CloudMeta{Provider: "azure", InstanceID: c.VMID, Region: c.Location,
+		Zone: c.Zone, InstanceType: c.VMSize}, nil
+}
+
+func init() {
+	collectCmd.Flags().BoolVar(&cloud, "cloud", false, "tag snapshots with cloud instance metadata (AWS, GCP or Azure, auto-detected once at startup)")
+}
@@ -2,12 +2,15 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"os/signal"
 	"runtime"
+	"slices"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -21,9 +24,25 @@ import (
 )
 
 var (
-	jsonOut  bool
-	interval time.Duration
-	count    int
+	jsonOut   bool
+	format    string
+	interval  time.Duration
+	count     int
+	duration  time.Duration
+	perCPU    bool
+	diskPaths []string
+	diskIO    bool
+	perNIC    bool
+	nics      []string
+	hostname  string
+	verbose   bool
+	warmup    bool
+	align     bool
+	cpuWindow time.Duration
+
+	// cpuSinceLast switches collectCPU from a --cpu-window measurement to
+	// the share of CPU since its previous call, once a stream is going.
+	cpuSinceLast bool
 )
 
 type Snapshot struct {
@@ -32,42 +51,377 @@ type Snapshot struct {
 	OS        string    `json:"os"`
 	UptimeSec uint64    `json:"uptime_sec"`
 
-	CPUPercent float64  `json:"cpu_percent"`
-	Load1      *float64 `json:"load1,omitempty"`
-	Load5      *float64 `json:"load5,omitempty"`
-	Load15     *float64 `json:"load15,omitempty"`
+	KernelVersion string `json:"kernel_version,omitempty"`
+	BootTime      string `json:"boot_time,omitempty"` // RFC3339
+
+	Cloud  *CloudMeta        `json:"cloud,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+
+	CPUPercent *float64 `json:"cpu_percent,omitempty"`
+
+	// CPUMaxPercent is the busiest of the --subsamples readings behind
+	// CPUPercent, with --subsample-max.
+	CPUMaxPercent *float64 `json:"cpu_max_percent,omitempty"`
+
+	CPUPerCore []float64 `json:"cpu_per_core,omitempty"`
+	CPUTimes   *CPUTimes `json:"cpu_times,omitempty"`
+	CPUInfo    *CPUInfo  `json:"cpu_info,omitempty"`
+	Load1      *float64  `json:"load1,omitempty"`
+	Load5      *float64  `json:"load5,omitempty"`
+	Load15     *float64  `json:"load15,omitempty"`
+
+	// LoadPerCore is Load1 divided by the logical CPU count, a saturation
+	// figure comparable across hosts of different sizes.
+	LoadPerCore *float64 `json:"load_per_core,omitempty"`
+
+	// LoadSynthetic marks Windows load figures approximated by
+	// --synthetic-load, which aren't comparable to a Unix load average.
+	LoadSynthetic bool `json:"load_synthetic,omitempty"`
+
+	MemUsedMB  *uint64  `json:"mem_used_mb,omitempty"`
+	MemTotalMB *uint64  `json:"mem_total_mb,omitempty"`
+	MemUsedPct *float64 `json:"mem_used_pct,omitempty"`
+
+	// Available counts reclaimable cache as free; cached and buffers are
+	// only reported on Linux.
+	MemAvailableMB *uint64 `json:"mem_available_mb,omitempty"`
+	MemCachedMB    *uint64 `json:"mem_cached_mb,omitempty"`
+	MemBuffersMB   *uint64 `json:"mem_buffers_mb,omitempty"`
+
+	// Deprecated: MemFreePct mirrors MemUsedPct under the old, mislabeled
+	// "mem_free_pct" key so existing JSON consumers keep working. It holds
+	// the used percentage and will be removed in a future release.
+	MemFreePct *float64 `json:"mem_free_pct,omitempty" deprecated:"true"`
+
+	// With --units: sizes formatted in the chosen unit plus raw bytes
+	MemUsed        string  `json:"mem_used,omitempty"`
+	MemTotal       string  `json:"mem_total,omitempty"`
+	MemUsedBytes   *uint64 `json:"mem_used_bytes,omitempty"`
+	MemTotalBytes  *uint64 `json:"mem_total_bytes,omitempty"`
+	SwapUsed       string  `json:"swap_used,omitempty"`
+	SwapTotal      string  `json:"swap_total,omitempty"`
+	SwapUsedBytes  *uint64 `json:"swap_used_bytes,omitempty"`
+	SwapTotalBytes *uint64 `json:"swap_total_bytes,omitempty"`
+
+	SwapUsedMB  *uint64  `json:"swap_used_mb,omitempty"`
+	SwapTotalMB *uint64  `json:"swap_total_mb,omitempty"`
+	SwapUsedPct *float64 `json:"swap_used_pct,omitempty"`
+
+	Disks  []DiskStat   `json:"disks,omitempty"`
+	DiskIO []DiskIOStat `json:"disk_io,omitempty"`
+
+	NetBytesIn     *uint64        `json:"net_bytes_in,omitempty"`
+	NetBytesOut    *uint64        `json:"net_bytes_out,omitempty"`
+	NetRateInBps   *float64       `json:"net_rate_in_bps,omitempty"`
+	NetRateOutBps  *float64       `json:"net_rate_out_bps,omitempty"`
+	NetErrIn       *uint64        `json:"net_err_in,omitempty"`
+	NetErrOut      *uint64        `json:"net_err_out,omitempty"`
+	NetDropIn      *uint64        `json:"net_drop_in,omitempty"`
+	NetDropOut     *uint64        `json:"net_drop_out,omitempty"`
+	NetErrInRate   *float64       `json:"net_err_in_per_sec,omitempty"`
+	NetErrOutRate  *float64       `json:"net_err_out_per_sec,omitempty"`
+	NetDropInRate  *float64       `json:"net_drop_in_per_sec,omitempty"`
+	NetDropOutRate *float64       `json:"net_drop_out_per_sec,omitempty"`
+	NetInterfaces  []NetStat      `json:"net_interfaces,omitempty"`
+	TCPStates      map[string]int `json:"tcp_states,omitempty"`
+	Ports          *PortSummary   `json:"ports,omitempty"`
+
+	CtxSwitches   *uint64  `json:"ctx_switches,omitempty"`
+	Interrupts    *uint64  `json:"interrupts,omitempty"`
+	CtxSwitchRate *float64 `json:"ctx_switches_per_sec,omitempty"`
+	InterruptRate *float64 `json:"interrupts_per_sec,omitempty"`
+
+	FDsOpen    *uint64  `json:"fds_open,omitempty"`
+	FDsMax     *uint64  `json:"fds_max,omitempty"`
+	FDsUsedPct *float64 `json:"fds_used_pct,omitempty"`
+
+	PSI *PSI `json:"psi,omitempty"`
+
+	Cgroup *CgroupStat `json:"cgroup,omitempty"`
+
+	Smoothed    *Smoothed    `json:"smoothed,omitempty"`
+	Percentiles *Percentiles `json:"percentiles,omitempty"`
+
+	// With --detect-anomalies (streaming only)
+	Anomaly   bool      `json:"anomaly,omitempty"`
+	Anomalies []Anomaly `json:"anomalies,omitempty"`
 
-	MemUsedMB  uint64  `json:"mem_used_mb"`
-	MemTotalMB uint64  `json:"mem_total_mb"`
-	MemUsedPct float64 `json:"mem_free_pct"`
+	// With --baseline: signed standard deviations from the baseline mean
+	BaselineSigma map[string]float64 `json:"baseline_sigma,omitempty"`
 
-	DiskPath    string  `json:"disk_path"`
-	DiskUsedGB  float64 `json:"disk_used_gb"`
-	DiskTotalGB float64 `json:"disk_total_gb"`
-	DiskUsedPct float64 `json:"disk_used_pct"`
+	UserCount *uint64       `json:"user_count,omitempty"`
+	Users     []UserSession `json:"users,omitempty"`
 
-	NetBytesIn  uint64 `json:"net_bytes_in"`
-	NetBytesOut uint64 `json:"net_bytes_out"`
+	ProcCount   *uint64 `json:"proc_count,omitempty"`
+	ThreadCount *uint64 `json:"thread_count,omitempty"`
+	ZombieCount *uint64 `json:"zombie_count,omitempty"`
+
+	Temperatures []SensorTemp `json:"temperatures,omitempty"`
+
+	BatteryPercent  *float64 `json:"battery_percent,omitempty"`
+	BatteryCharging *bool    `json:"battery_charging,omitempty"`
+
+	GPUs []GPUStat `json:"gpus,omitempty"`
+
+	Processes []ProcStat `json:"processes,omitempty"`
+
+	// Custom holds the values reported by --exec commands.
+	Custom map[string]float64 `json:"custom,omitempty"`
+
+	// Errors maps a collector name (cpu, mem, disk, ...) to why it failed
+	// or only partly succeeded this cycle, so a missing or zeroed metric
+	// can be told apart from a genuine reading.
+	Errors map[string]string `json:"errors,omitempty"`
+
+	// Raw counters kept for computing deltas against the next sample
+	cpuTimesRaw *cpu.TimesStat
 }
 
-func humanHeader() string {
-	return "TIME\tCPU%\tLoad1\tMEM_USED/TOTAL(MB)\tMEM%\tDISK%\tNET_IN/NET_OUT(B)\tHOST"
+// DiskStat is the usage of the filesystem holding a single path.
+type DiskStat struct {
+	Path    string  `json:"path"`
+	UsedGB  float64 `json:"used_gb"`
+	TotalGB float64 `json:"total_gb"`
+	UsedPct float64 `json:"used_pct"`
+
+	// Some filesystems (btrfs, many network mounts) report no inodes at
+	// all; InodesUsedPct is left out for them.
+	InodesUsed    uint64   `json:"inodes_used"`
+	InodesTotal   uint64   `json:"inodes_total"`
+	InodesUsedPct *float64 `json:"inodes_used_pct,omitempty"`
+
+	// With --units
+	Used       string `json:"used,omitempty"`
+	Total      string `json:"total,omitempty"`
+	UsedBytes  uint64 `json:"used_bytes,omitempty"`
+	TotalBytes uint64 `json:"total_bytes,omitempty"`
 }
 
-func (s Snapshot) humanRow() string {
-	load1 := "-"
-	if s.Load1 != nil {
-		load1 = fmt.Sprintf("%.2f", *s.Load1)
+// DiskIOStat holds the cumulative I/O counters of one block device and, in
+// streaming mode, their per-second rates since the previous sample.
+type DiskIOStat struct {
+	Name       string `json:"name"`
+	ReadBytes  uint64 `json:"read_bytes"`
+	WriteBytes uint64 `json:"write_bytes"`
+	ReadCount  uint64 `json:"read_count"`
+	WriteCount uint64 `json:"write_count"`
+
+	ReadBps   *float64 `json:"read_bps,omitempty"`
+	WriteBps  *float64 `json:"write_bps,omitempty"`
+	ReadOpsS  *float64 `json:"read_ops_per_sec,omitempty"`
+	WriteOpsS *float64 `json:"write_ops_per_sec,omitempty"`
+}
+
+// NetStat holds the cumulative counters of one network interface.
+type NetStat struct {
+	Name       string `json:"name"`
+	BytesIn    uint64 `json:"bytes_in"`
+	BytesOut   uint64 `json:"bytes_out"`
+	PacketsIn  uint64 `json:"packets_in"`
+	PacketsOut uint64 `json:"packets_out"`
+	ErrIn      uint64 `json:"err_in"`
+	ErrOut     uint64 `json:"err_out"`
+	DropIn     uint64 `json:"drop_in"`
+	DropOut    uint64 `json:"drop_out"`
+}
+
+func humanHeader(color bool) string {
+	h := "TIME\t" + colorHeader("CPU%", color) + "\tLoad1\t" + humanMemHeader() + "\t" + colorHeader("MEM%", color) + "\tSWAP%\t" + diskHeader(color) + "\tNET_IN/NET_OUT(B)\tUPTIME\tHOST"
+	if verbose {
+		h += "\tKERNEL\tBOOT"
+	}
+	if perCPU {
+		h += "\tCORES"
+	}
+	if subsampleMax {
+		h += "\tCPU_MAX%"
+	}
+	if cpuInfo {
+		h += "\tMHZ"
+	}
+	if temps {
+		h += "\tTEMP"
+	}
+	if users {
+		h += "\tUSERS"
 	}
-	return fmt.Sprintf("%s\t%.1f\t%s\t%d/%d\t\t%.1f\t%.1f\t%d/%d\t%s",
-		s.Timestamp.Format("15:04:05"),
-		s.CPUPercent,
-		load1,
-		s.MemUsedMB, s.MemTotalMB,
-		s.MemUsedPct,
-		s.DiskUsedPct,
-		s.NetBytesIn, s.NetBytesOut,
+	if procCounts {
+		h += "\tPROCS/THR/ZOMB"
+	}
+	if cgroupStats {
+		h += "\tCG_MEM%\tCG_CPU%"
+	}
+	if psi {
+		h += "\tPSI_CPU/MEM/IO"
+	}
+	if battery {
+		h += "\tBATT"
+	}
+	if gpus {
+		h += "\tGPU%/MEM%"
+	}
+	if smoothN > 1 {
+		h += "\t" + smoothHeader()
+	}
+	if len(pcts) > 0 {
+		h += "\t" + percentilesHeader()
+	}
+	if detectAnomalies {
+		h += "\tANOMALY"
+	}
+	if loadedBaseline != nil {
+		h += "\tBASELINE"
+	}
+	return h
+}
+
+func (s Snapshot) humanRow(color bool) string {
+	row := fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s/%s\t%s\t%s",
+		formatTimestamp(s.Timestamp, "15:04:05"),
+		colorPct(optFloat(s.CPUPercent, 1), s.CPUPercent, color),
+		s.humanLoad(),
+		s.humanMem(),
+		colorPct(optFloat(s.MemUsedPct, 1), s.MemUsedPct, color),
+		optFloat(s.SwapUsedPct, 1),
+		s.diskColumns(color),
+		optUint(s.NetBytesIn), optUint(s.NetBytesOut),
+		FormatUptime(s.UptimeSec),
 		s.Host)
+	if verbose {
+		row += "\t" + orDash(s.KernelVersion) + "\t" + orDash(s.BootTime)
+	}
+	if perCPU {
+		row += "\t" + formatPerCore(s.CPUPerCore)
+	}
+	if subsampleMax {
+		row += "\t" + optFloat(s.CPUMaxPercent, 1)
+	}
+	if cpuInfo {
+		row += "\t" + s.humanCPUMHz()
+	}
+	if temps {
+		row += "\t" + hottestTemp(s.Temperatures)
+	}
+	if users {
+		row += "\t" + s.humanUsers()
+	}
+	if procCounts {
+		row += "\t" + s.humanProcCounts()
+	}
+	if cgroupStats {
+		row += "\t" + s.Cgroup.humanColumns()
+	}
+	if psi {
+		row += "\t" + s.humanPSI()
+	}
+	if battery {
+		row += "\t" + s.humanBattery()
+	}
+	if gpus {
+		row += "\t" + s.humanGPUs()
+	}
+	if smoothN > 1 {
+		row += "\t" + s.Smoothed.humanColumns()
+	}
+	if len(pcts) > 0 {
+		row += "\t" + s.Percentiles.humanColumns()
+	}
+	if detectAnomalies {
+		row += "\t" + s.humanAnomalies()
+	}
+	if loadedBaseline != nil {
+		row += "\t" + s.humanBaseline()
+	}
+	return row
+}
+
+// orDash stands in "-" for an empty string cell.
+func orDash(v string) string {
+	if v == "" {
+		return "-"
+	}
+	return v
+}
+
+// optFloat formats an optional value with prec decimals, or "-" when the
+// metric wasn't collected.
+func optFloat(v *float64, prec int) string {
+	if v == nil {
+		return "-"
+	}
+	return fmtFloat(*v, prec)
+}
+
+func optUint(v *uint64) string {
+	if v == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d", *v)
+}
+
+// ptr returns a pointer to a copy of v, for filling optional fields.
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// diskHeader returns one DISK% column per configured path. The single-root
+// default keeps the original bare "DISK%" heading.
+func diskHeader(color bool) string {
+	if len(diskPaths) == 0 {
+		return colorHeader("DISK%", color)
+	}
+	cols := make([]string, len(diskPaths))
+	for i, p := range diskPaths {
+		cols[i] = colorHeader(fmt.Sprintf("DISK%%(%s)", p), color)
+	}
+	return strings.Join(cols, "\t")
+}
+
+// diskColumns renders used percent for each configured path in header order,
+// printing "-" for paths that could not be read this sample.
+func (s Snapshot) diskColumns(color bool) string {
+	targets := diskTargets()
+	cols := make([]string, len(targets))
+	for i, p := range targets {
+		cols[i] = colorPct("-", nil, color)
+		for _, d := range s.Disks {
+			if d.Path == p {
+				cols[i] = colorPct(fmtFloat(d.UsedPct, 1), &d.UsedPct, color)
+				break
+			}
+		}
+	}
+	return strings.Join(cols, "\t")
+}
+
+// FormatUptime renders a duration in seconds compactly, e.g. "3d4h12m".
+// Zero units are dropped and seconds are only shown below one minute, so
+// 0 is "0s", 45 is "45s" and 90061 is "1d1h1m".
+func FormatUptime(seconds uint64) string {
+	if seconds < 60 {
+		return fmt.Sprintf("%ds", seconds)
+	}
+	d, h, m := seconds/86400, seconds%86400/3600, seconds%3600/60
+	var b strings.Builder
+	if d > 0 {
+		fmt.Fprintf(&b, "%dd", d)
+	}
+	if h > 0 {
+		fmt.Fprintf(&b, "%dh", h)
+	}
+	if m > 0 {
+		fmt.Fprintf(&b, "%dm", m)
+	}
+	return b.String()
+}
+
+// formatPerCore renders per-core percentages as "c0=12.3 c1=0.0 ...".
+func formatPerCore(pcts []float64) string {
+	parts := make([]string, len(pcts))
+	for i, p := range pcts {
+		parts[i] = fmt.Sprintf("c%d=%s", i, fmtFloat(p, 1))
+	}
+	return strings.Join(parts, " ")
 }
 
 func getRootPath() string {
@@ -84,54 +438,528 @@ func getRootPath() string {
 	return "/"
 }
 
+// diskTargets returns the paths to report disk usage for, falling back to
+// the root filesystem when no --disk-path was given.
+func diskTargets() []string {
+	if len(diskPaths) == 0 {
+		return []string{getRootPath()}
+	}
+	return diskPaths
+}
+
+// reportedHostname is the --hostname override, else $HOSTNAME; empty means
+// keep the name gopsutil reports.
+func reportedHostname() string {
+	if hostname != "" {
+		return hostname
+	}
+	return os.Getenv("HOSTNAME")
+}
+
+// localHostname is the name local snapshots are stamped with, for sinks
+// that identify themselves before any sample is taken.
+func localHostname() string {
+	name := reportedHostname()
+	if name == "" {
+		name, _ = os.Hostname()
+	}
+	if redact {
+		return redactHost(name)
+	}
+	return name
+}
+
+// collector gathers one subsystem. run returns the assignment that merges
+// its readings into the Snapshot, or nil when it has nothing to report, and
+// whatever went wrong; a partial reading comes back with both.
+// Collectors run concurrently, so they must not touch the Snapshot
+// themselves; collectOnce applies the assignments one by one afterwards.
+type collector struct {
+	name string
+	run  func(ctx context.Context) (func(*Snapshot), error)
+}
+
 func collectOnce(ctx context.Context) (Snapshot, error) {
+	if collectTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, collectTimeout)
+		defer cancel()
+	}
+	if remote != nil {
+		snap, err := remote.collect(ctx)
+		snap.applyRedact()
+		return snap, err
+	}
+
 	var snap Snapshot
 	now := time.Now()
+	if tsLocation != nil {
+		now = now.In(tsLocation)
+	}
 	snap.Timestamp = now
 
-	hi, _ := host.InfoWithContext(ctx)
-	if hi != nil {
-		snap.Host = hi.Hostname
-		snap.OS = fmt.Sprintf("%s/%s", hi.OS, hi.Platform)
-		snap.UptimeSec = hi.Uptime
+	cs := collectors()
+	failed := runCollectors(ctx, cs, &snap)
+
+	if name := reportedHostname(); name != "" {
+		snap.Host = name
+	}
+	snap.Labels = labels
+	snap.applyRedact()
+
+	if collectTimeout > 0 {
+		warnTimedOut(ctx)
+	}
+	if failed == len(cs) {
+		return snap, fmt.Errorf("every collector failed: %v", snap.Errors)
 	}
+	return snap, nil
+}
 
-	// CPU percent (since last call); with interval=10 it uses a short sample window
-	pcts, err := cpu.PercentWithContext(ctx, 200*time.Millisecond, false)
-	if err == nil && len(pcts) > 0 {
-		snap.CPUPercent = pcts[0]
+// runCollectors fans cs out and applies their results to snap in order,
+// recording each failure in snap.Errors. errUnsupported is not a failure:
+// the metric is simply left out. It returns how many collectors failed.
+func runCollectors(ctx context.Context, cs []collector, snap *Snapshot) (failed int) {
+	// Fan out so a cycle takes as long as the slowest collector (usually the
+	// CPU window) rather than the sum of them all.
+	type result struct {
+		i     int
+		apply func(*Snapshot)
+		err   error
+	}
+	results := make(chan result, len(cs))
+	var wg sync.WaitGroup
+	for i, c := range cs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			apply, err := runCollector(ctx, c)
+			if err != nil {
+				slog.Debug("collector failed", "collector", c.name, "err", err)
+			}
+			results <- result{i, apply, err}
+		}()
 	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	// A collector stuck in a call that ignores ctx (statfs on a stale NFS
+	// mount) is abandoned once ctx is done; its result is never applied.
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+	got := make([]*result, len(cs))
+drain:
+	for {
+		select {
+		case r := <-results:
+			got[r.i] = &r
+		default:
+			break drain
+		}
+	}
+	for i, r := range got {
+		err := ctx.Err() // abandoned
+		if r != nil {
+			if r.apply != nil {
+				r.apply(snap)
+			}
+			err = r.err
+		}
+		if err != nil && !errors.Is(err, errUnsupported) {
+			if snap.Errors == nil {
+				snap.Errors = map[string]string{}
+			}
+			snap.Errors[cs[i].name] = err.Error()
+			failed++
+		}
+	}
+	return failed
+}
 
-	// Load averages
-	if runtime.GOOS != "windows" {
-		if l, err := load.AvgWithContext(ctx); err == nil && l != nil {
-			snap.Load1, snap.Load5, snap.Load15 = &l.Load1, &l.Load5, &l.Load15
+// collectors returns the enabled collectors in Snapshot field order, which
+// is also the order their results are applied in.
+func collectors() []collector {
+	cs := []collector{{"host", collectHost}}
+	if cloud {
+		cs = append(cs, collector{"cloud", func(context.Context) (func(*Snapshot), error) {
+			m := cloudInfo
+			return func(s *Snapshot) { s.Cloud = m }, nil
+		}})
+	}
+	if groupEnabled(groupCPU) {
+		cs = append(cs, collector{"cpu", collectCPU})
+		// CPU model and clock (opt-in; the model is read once)
+		if cpuInfo {
+			cs = append(cs, collector{"cpu-info", func(ctx context.Context) (func(*Snapshot), error) {
+				ci, err := readCPUInfo(ctx)
+				if err != nil {
+					return nil, err
+				}
+				return func(s *Snapshot) { s.CPUInfo = ci }, nil
+			}})
+		}
+	}
+	if groupEnabled(groupLoad) {
+		if runtime.GOOS != "windows" {
+			cs = append(cs, collector{"load", collectLoad})
+		} else if syntheticLoad {
+			cs = append(cs, collector{"load", collectSyntheticLoad})
 		}
 	}
+	if groupEnabled(groupMem) {
+		cs = append(cs, collector{"mem", collectMem})
+	}
+	if groupEnabled(groupDisk) {
+		cs = append(cs, collector{"disk", collectDisks})
+		// Disk I/O counters per device (opt-in; enumerating devices has a cost)
+		if diskIO {
+			cs = append(cs, collector{"disk-io", collectDiskIO})
+		}
+	}
+	if groupEnabled(groupNet) {
+		cs = append(cs, collector{"net", collectNet})
+	}
 
-	// Memory
-	if vm, err := mem.VirtualMemoryWithContext(ctx); err == nil && vm != nil {
-		snap.MemUsedMB = uint64(vm.Used / (1024 * 1024))
-		snap.MemTotalMB = uint64(vm.Total / (1024 * 1024))
-		snap.MemUsedPct = vm.UsedPercent
+	// TCP connection states and ports (opt-in; walks every socket)
+	if connections || ports {
+		cs = append(cs, collector{"connections", collectConnections})
 	}
 
-	// Disk Usage on root
-	root := getRootPath()
-	if du, err := disk.UsageWithContext(ctx, root); err == nil && du != nil {
-		snap.DiskPath = root
-		snap.DiskUsedGB = float64(du.Used) / (1024 * 1024 * 1024)
-		snap.DiskTotalGB = float64(du.Total) / (1024 * 1024 * 1024)
-		snap.DiskUsedPct = du.UsedPercent
+	// Context switches and interrupts (opt-in; Linux only)
+	if kernelStats {
+		cs = append(cs, collector{"kernel-stats", func(context.Context) (func(*Snapshot), error) {
+			ctxt, intr, err := readKernelStats()
+			if err != nil {
+				return nil, err
+			}
+			return func(s *Snapshot) { s.CtxSwitches, s.Interrupts = &ctxt, &intr }, nil
+		}})
 	}
 
-	// Net I/O (all interfaces aggregated)
-	if ios, err := net.IOCountersWithContext(ctx, false); err == nil && len(ios) > 0 {
-		snap.NetBytesIn = ios[0].BytesRecv
-		snap.NetBytesOut = ios[0].BytesSent
+	// Pressure stall information (opt-in; Linux 4.20+)
+	if psi {
+		cs = append(cs, collector{"psi", func(context.Context) (func(*Snapshot), error) {
+			p, err := readPSI()
+			if err != nil {
+				return nil, err
+			}
+			return func(s *Snapshot) { s.PSI = p }, nil
+		}})
 	}
 
-	return snap, nil
+	// System-wide file descriptors (opt-in; Linux only)
+	if fds {
+		cs = append(cs, collector{"fds", func(context.Context) (func(*Snapshot), error) {
+			open, limit, err := readFDs()
+			if err != nil {
+				return nil, err
+			}
+			return func(s *Snapshot) {
+				s.FDsOpen, s.FDsMax = &open, &limit
+				if limit > 0 {
+					s.FDsUsedPct = ptr(float64(open) / float64(limit) * 100)
+				}
+			}, nil
+		}})
+	}
+
+	// Container limits (opt-in; Linux only)
+	if cgroupStats {
+		cs = append(cs, collector{"cgroup", func(context.Context) (func(*Snapshot), error) {
+			cg, err := readCgroup()
+			if err != nil {
+				return nil, err
+			}
+			return func(s *Snapshot) { s.Cgroup = cg }, nil
+		}})
+	}
+
+	// Logged-in sessions (opt-in)
+	if users {
+		cs = append(cs, collector{"users", collectUsers})
+	}
+
+	// Process, thread and zombie counts (opt-in; walks every PID)
+	if procCounts {
+		cs = append(cs, collector{"procs", func(ctx context.Context) (func(*Snapshot), error) {
+			procs, threads, zombies, err := countProcs(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return func(s *Snapshot) { s.ProcCount, s.ThreadCount, s.ZombieCount = &procs, &threads, &zombies }, nil
+		}})
+	}
+
+	// Temperature sensors (opt-in; many platforms report nothing)
+	if temps {
+		cs = append(cs, collector{"temps", func(ctx context.Context) (func(*Snapshot), error) {
+			ts := readTemps(ctx)
+			return func(s *Snapshot) { s.Temperatures = ts }, nil
+		}})
+	}
+
+	// Battery (opt-in; left out on machines without one)
+	if battery {
+		cs = append(cs, collector{"battery", func(context.Context) (func(*Snapshot), error) {
+			pct, charging, err := readBattery()
+			if err != nil {
+				return nil, err
+			}
+			return func(s *Snapshot) { s.BatteryPercent, s.BatteryCharging = &pct, &charging }, nil
+		}})
+	}
+
+	// NVIDIA GPUs (opt-in; left out on machines without nvidia-smi)
+	if gpus {
+		cs = append(cs, collector{"gpu", func(ctx context.Context) (func(*Snapshot), error) {
+			gs, err := readGPUs(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return func(s *Snapshot) { s.GPUs = gs }, nil
+		}})
+	}
+
+	// Top processes (opt-in)
+	if topN > 0 {
+		cs = append(cs, collector{"top", func(ctx context.Context) (func(*Snapshot), error) {
+			procs, err := topProcesses(ctx, topN)
+			if err != nil {
+				return nil, err
+			}
+			return func(s *Snapshot) { s.Processes = procs }, nil
+		}})
+	}
+
+	// External commands' custom metrics (opt-in)
+	for _, c := range execCmds {
+		cs = append(cs, execCollector(c))
+	}
+	return cs
+}
+
+func collectHost(ctx context.Context) (func(*Snapshot), error) {
+	hi, err := host.InfoWithContext(ctx)
+	if hi == nil {
+		return nil, err
+	}
+	// gopsutil returns partial info alongside an error; keep what it has
+	return func(s *Snapshot) {
+		s.Host = hi.Hostname
+		s.OS = fmt.Sprintf("%s/%s", hi.OS, hi.Platform)
+		s.UptimeSec = hi.Uptime
+		s.KernelVersion = hi.KernelVersion
+		if hi.BootTime > 0 {
+			boot := time.Unix(int64(hi.BootTime), 0)
+			if tsLocation != nil {
+				boot = boot.In(tsLocation)
+			}
+			s.BootTime = boot.Format(time.RFC3339)
+		}
+	}, err
+}
+
+// collectCPU takes the CPU percent over a --cpu-window sample window or, in
+// a running stream, over the whole interval since the previous sample.
+func collectCPU(ctx context.Context) (func(*Snapshot), error) {
+	// Bracket the percent window with times readings so even a single
+	// sample gets a mode breakdown; streams refine it in applyRates.
+	var t0, t1 *cpu.TimesStat
+	if cpuTimes {
+		t0 = readCPUTimes(ctx)
+	}
+	window := cpuWindow
+	if cpuSinceLast {
+		window = 0 // gopsutil compares against its previous zero-window call
+	}
+	var pcts []float64
+	var sub *subsampleStats
+	var err error
+	if subsamples > 1 && !cpuSinceLast {
+		pcts, sub, err = subsampleWindow(ctx, window)
+	} else {
+		pcts, err = cpu.PercentWithContext(ctx, window, perCPU)
+		if subsamples > 1 {
+			sub = cpuSubs.drain(ctx)
+		}
+	}
+	if cpuTimes {
+		t1 = readCPUTimes(ctx)
+	}
+	return func(s *Snapshot) {
+		if err == nil && len(pcts) > 0 {
+			if perCPU {
+				// Derive the aggregate from the same window rather than sampling twice
+				var sum float64
+				for _, p := range pcts {
+					sum += p
+				}
+				s.CPUPercent = ptr(sum / float64(len(pcts)))
+				s.CPUPerCore = pcts
+			} else {
+				s.CPUPercent = ptr(pcts[0])
+			}
+		}
+		if sub != nil {
+			s.CPUPercent = ptr(sub.mean)
+			if subsampleMax {
+				s.CPUMaxPercent = ptr(sub.max)
+			}
+		}
+		s.cpuTimesRaw = t1
+		if t0 != nil && t1 != nil {
+			s.CPUTimes = cpuTimesPct(*t0, *t1)
+		}
+	}, err
+}
+
+func collectLoad(ctx context.Context) (func(*Snapshot), error) {
+	l, err := load.AvgWithContext(ctx)
+	if err != nil || l == nil {
+		return nil, err
+	}
+	n, err := cpu.CountsWithContext(ctx, true)
+	return func(s *Snapshot) {
+		s.Load1, s.Load5, s.Load15 = &l.Load1, &l.Load5, &l.Load15
+		if err == nil && n > 0 {
+			s.LoadPerCore = ptr(l.Load1 / float64(n))
+		}
+	}, err
+}
+
+func collectMem(ctx context.Context) (func(*Snapshot), error) {
+	vm, vmErr := mem.VirtualMemoryWithContext(ctx)
+	sw, swErr := mem.SwapMemoryWithContext(ctx)
+	return func(s *Snapshot) {
+		if vmErr == nil && vm != nil {
+			s.MemUsedMB = ptr(vm.Used / (1024 * 1024))
+			s.MemTotalMB = ptr(vm.Total / (1024 * 1024))
+			s.MemUsedPct = ptr(vm.UsedPercent)
+			s.MemFreePct = ptr(vm.UsedPercent)
+			s.MemAvailableMB = ptr(vm.Available / (1024 * 1024))
+			if runtime.GOOS == "linux" {
+				s.MemCachedMB = ptr(vm.Cached / (1024 * 1024))
+				s.MemBuffersMB = ptr(vm.Buffers / (1024 * 1024))
+			}
+			if sizeUnits != "" {
+				s.memSizes(vm.Used, vm.Total)
+			}
+		}
+		if swErr == nil && sw != nil {
+			s.SwapUsedMB = ptr(sw.Used / (1024 * 1024))
+			s.SwapTotalMB = ptr(sw.Total / (1024 * 1024))
+			// Swap may be disabled entirely; report 0% rather than dividing by zero
+			pct := 0.0
+			if sw.Total > 0 {
+				pct = float64(sw.Used) / float64(sw.Total) * 100
+			}
+			s.SwapUsedPct = &pct
+			if sizeUnits != "" {
+				s.swapSizes(sw.Used, sw.Total)
+			}
+		}
+	}, errors.Join(vmErr, swErr)
+}
+
+// collectDisks reports usage on each target path (root by default).
+func collectDisks(ctx context.Context) (func(*Snapshot), error) {
+	var disks []DiskStat
+	var errs []error
+	for _, p := range diskTargets() {
+		du, err := disk.UsageWithContext(ctx, p)
+		if err != nil || du == nil {
+			if discoveredMounts[p] {
+				slog.Debug("skipping mount", "path", p, "err", err)
+			} else {
+				noteDiskPath(p, err)
+				errs = append(errs, fmt.Errorf("%s: %w", p, err))
+			}
+			continue
+		}
+		if !discoveredMounts[p] {
+			noteDiskPath(p, nil)
+		}
+		d := DiskStat{
+			Path:        p,
+			UsedGB:      float64(du.Used) / (1024 * 1024 * 1024),
+			TotalGB:     float64(du.Total) / (1024 * 1024 * 1024),
+			UsedPct:     du.UsedPercent,
+			InodesUsed:  du.InodesUsed,
+			InodesTotal: du.InodesTotal,
+		}
+		if sizeUnits != "" {
+			d.Used, d.Total = formatSize(du.Used), formatSize(du.Total)
+			d.UsedBytes, d.TotalBytes = du.Used, du.Total
+		}
+		if du.InodesTotal > 0 {
+			d.InodesUsedPct = ptr(float64(du.InodesUsed) / float64(du.InodesTotal) * 100)
+		}
+		disks = append(disks, d)
+	}
+	return func(s *Snapshot) { s.Disks = disks }, errors.Join(errs...)
+}
+
+func collectDiskIO(ctx context.Context) (func(*Snapshot), error) {
+	ios, err := disk.IOCountersWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var stats []DiskIOStat
+	for name, io := range ios {
+		stats = append(stats, DiskIOStat{
+			Name:       name,
+			ReadBytes:  io.ReadBytes,
+			WriteBytes: io.WriteBytes,
+			ReadCount:  io.ReadCount,
+			WriteCount: io.WriteCount,
+		})
+	}
+	slices.SortFunc(stats, func(a, b DiskIOStat) int { return strings.Compare(a.Name, b.Name) })
+	return func(s *Snapshot) { s.DiskIO = stats }, nil
+}
+
+// collectNet reads the counters aggregated over all interfaces and, when
+// asked, per interface.
+func collectNet(ctx context.Context) (func(*Snapshot), error) {
+	total, err := net.IOCountersWithContext(ctx, false)
+	var ifaces []NetStat
+	var nicErr error
+	if perNIC || len(nics) > 0 {
+		var ios []net.IOCountersStat
+		if ios, nicErr = net.IOCountersWithContext(ctx, true); nicErr == nil {
+			for _, io := range ios {
+				if len(nics) > 0 && !slices.Contains(nics, io.Name) {
+					continue
+				}
+				ifaces = append(ifaces, NetStat{
+					Name:       io.Name,
+					BytesIn:    io.BytesRecv,
+					BytesOut:   io.BytesSent,
+					PacketsIn:  io.PacketsRecv,
+					PacketsOut: io.PacketsSent,
+					ErrIn:      io.Errin,
+					ErrOut:     io.Errout,
+					DropIn:     io.Dropin,
+					DropOut:    io.Dropout,
+				})
+			}
+		}
+	}
+	return func(s *Snapshot) {
+		if err == nil && len(total) > 0 {
+			s.NetBytesIn = ptr(total[0].BytesRecv)
+			s.NetBytesOut = ptr(total[0].BytesSent)
+			s.NetErrIn = ptr(total[0].Errin)
+			s.NetErrOut = ptr(total[0].Errout)
+			s.NetDropIn = ptr(total[0].Dropin)
+			s.NetDropOut = ptr(total[0].Dropout)
+		}
+		s.NetInterfaces = ifaces
+	}, errors.Join(err, nicErr)
 }
 
 var collectCmd = &cobra.Command{
@@ -141,19 +969,147 @@ var collectCmd = &cobra.Command{
 		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 		defer cancel()
 
+		outFmt, err := resolveFormat()
+		if err != nil {
+			return err
+		}
+		if err := resolveGroups(); err != nil {
+			return err
+		}
+		if err := resolveTimezone(); err != nil {
+			return err
+		}
+		if err := resolveColor(); err != nil {
+			return err
+		}
+		if err := resolveLabels(); err != nil {
+			return err
+		}
+		fileFmt, err := resolveOutputFormat(outFmt)
+		if err != nil {
+			return err
+		}
+		if err := resolveFields(outFmt, fileFmt); err != nil {
+			return err
+		}
+		if err := resolveUnits(); err != nil {
+			return err
+		}
+		if err := resolveDisks(ctx); err != nil {
+			return err
+		}
+		if err := resolveFailPolicy(cmd); err != nil {
+			return err
+		}
+		if err := validateRetryFlags(); err != nil {
+			return err
+		}
+		if err := validateJitter(); err != nil {
+			return err
+		}
+		if cpuWindow <= 0 {
+			return fmt.Errorf("--cpu-window must be positive, got %s", cpuWindow)
+		}
+		if interval > 0 && cpuWindow > interval && !highRes {
+			slog.Warn("--cpu-window is longer than --interval; samples will overlap and fall behind", "cpu_window", cpuWindow, "interval", interval)
+		}
+		if smoothN < 0 {
+			return fmt.Errorf("--smooth must not be negative, got %d", smoothN)
+		}
+		if err := resolvePercentiles(); err != nil {
+			return err
+		}
+		if err := validateAnomalyFlags(); err != nil {
+			return err
+		}
+		if err := validateSubsamples(); err != nil {
+			return err
+		}
+		if err := resolveIdle(); err != nil {
+			return err
+		}
+		if err := resolveBaseline(); err != nil {
+			return err
+		}
+		if topSort != "cpu" && topSort != "mem" {
+			return fmt.Errorf("unknown --top-sort %q (want cpu or mem)", topSort)
+		}
+		if dryRun {
+			cmd.SilenceUsage = true
+			return dryRunReport(ctx, os.Stdout, cmd.Flags(), outFmt)
+		}
+		if err := validateDiskPaths(ctx); err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+		if sshTarget != "" {
+			r, err := dialRemote(ctx, cmd.Flags())
+			if err != nil {
+				return err
+			}
+			remote = r
+			defer r.close()
+		}
+		resolveCloud(ctx)
+
+		if pidFile != "" {
+			remove, err := writePidfile(ctx, pidFile)
+			if err != nil {
+				return err
+			}
+			defer remove()
+		}
+
+		w, err := openOutput(fileFmt)
+		if err != nil {
+			return err
+		}
+		defer w.Close()
+
+		extra, err := openSinks(ctx)
+		if err != nil {
+			return err
+		}
+		// Closing flushes buffered sinks; it runs on ctrl-c too since the
+		// signal only cancels ctx.
+		defer func() {
+			if err := extra.close(); err != nil {
+				slog.Warn("closing outputs", "err", err)
+			}
+		}()
+
+		// --warmup takes a throwaway sample whose counters seed the rates,
+		// so even the first emitted sample has them.
+		var prev *Snapshot
+		if warmup {
+			seed, err := collectOnce(ctx)
+			if _, err := collectFailure(cmd, seed, err, false); err != nil {
+				return err
+			}
+			prev = &seed
+		}
+
 		if interval <= 0 {
 			snap, err := collectOnce(ctx)
-			if err != nil {
+			if _, err := collectFailure(cmd, snap, err, false); err != nil {
+				return err
+			}
+			snap.applyRates(prev)
+			snap.applyBaseline()
+			out := newEmitters(w, outFmt, fileFmt, false)
+			if err := out.begin(); err != nil {
+				return err
+			}
+			if err := out.emit(snap); err != nil {
 				return err
 			}
-			if jsonOut {
-				enc := json.NewEncoder(os.Stdout)
-				enc.SetIndent("", "  ")
-				return enc.Encode(snap)
+			extra.write(ctx, snap)
+			var sum *summary
+			if summaryOut {
+				sum = newSummary()
+				sum.add(snap)
 			}
-			fmt.Println(humanHeader())
-			fmt.Println(snap.humanRow())
-			return nil
+			return finishRun(cmd, &snap, sum)
 		}
 
 		// Streaming mode
@@ -163,38 +1119,168 @@ var collectCmd = &cobra.Command{
 		t := time.NewTicker(interval)
 		defer t.Stop()
 
-		if !jsonOut {
-			fmt.Println(humanHeader())
+		out := newEmitters(w, outFmt, fileFmt, true)
+		if err := out.begin(); err != nil {
+			return err
+		}
+
+		// A nil channel never fires, so without --duration only count/ctrl-c end the run
+		var deadline <-chan time.Time
+		if duration > 0 {
+			deadline = time.After(duration)
 		}
 
+		var sum *summary
+		if summaryOut {
+			sum = newSummary()
+		}
+
+		smooth := newSmoother(smoothN)
+		tails := newPctTracker()
+		anomalies := newAnomalyDetector()
+		idle := newIdleWatch()
+		var stats *runStats
 		i := 0
-		for {
+		// sample takes, emits and records one snapshot, reporting whether
+		// --count has been reached.
+		sample := func() (bool, error) {
+			start := time.Now()
+			snap, err := collectOnce(ctx)
+			stats.sampled(start, time.Since(start))
+			if skip, err := collectFailure(cmd, snap, err, true); skip || err != nil {
+				return false, err
+			}
+			snap.applyRates(prev)
+			smooth.apply(&snap)
+			tails.apply(&snap)
+			anomalies.apply(&snap)
+			snap.applyBaseline()
+			prev = &snap
+			if err := out.emit(snap); err != nil {
+				return false, err
+			}
+			extra.write(ctx, snap)
+			i++
+			return idle.observe(snap, sum) || count > 0 && i >= count, nil
+		}
+
+		// With --align the stream starts on the next multiple of the interval
+		// (:00, :10, :20 for 10s) so hosts sample at the same instants.
+		if align {
+			wait := time.Until(time.Now().Truncate(interval).Add(interval).Add(-jitter))
+			if wait < 0 {
+				wait += interval
+			}
 			select {
 			case <-ctx.Done():
-				return nil
-			case <-t.C:
-				snap, err := collectOnce(ctx)
-				if err != nil {
-					return err
-				}
-				if jsonOut {
-					b, _ := json.Marshal(snap)
-					fmt.Println(string(b))
-				} else {
-					fmt.Println(snap.humanRow())
+				return finishRun(cmd, nil, sum)
+			case <-time.After(wait):
+			}
+			t.Reset(interval)
+			if !waitJitter(ctx, deadline) {
+				return finishRun(cmd, nil, sum)
+			}
+		}
+
+		// The first sample is taken immediately, like top/vmstat, rather than
+		// after a full interval; the ticker keeps the cadence from here on.
+		// It measures CPU over --cpu-window, while the seed call here starts
+		// the clock that later samples measure the full interval against,
+		// so bursts between samples aren't missed.
+		//
+		// --high-res instead starts on the first deadline after a probe
+		// collection and has no CPU window to wait for.
+		tick := t.C
+		done := false
+		if highRes {
+			t.Stop()
+			hr := startHighRes(ctx, &prev)
+			defer hr.stop()
+			tick = hr.C
+			stats = newRunStats(hr.every, hr.start)
+			if subsamples > 1 && remote == nil {
+				cpuSubs = startSubsampler(ctx, hr.every)
+				defer cpuSubs.stop()
+			}
+		} else {
+			stats = newRunStats(interval, time.Now())
+			cpu.PercentWithContext(ctx, 0, perCPU)
+			done, err = sample()
+			cpuSinceLast = true
+			if subsamples > 1 && remote == nil {
+				cpuSubs = startSubsampler(ctx, interval)
+				defer cpuSubs.stop()
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+	loop:
+		for !done {
+			select {
+			case <-ctx.Done():
+				break loop
+			case <-deadline:
+				break loop
+			case tk := <-tick:
+				stats.tick(tk)
+				if !waitJitter(ctx, deadline) {
+					break loop
 				}
-				i++
-				if count > 0 && i >= count {
-					return nil
+				if done, err = sample(); err != nil {
+					return err
 				}
 			}
 		}
+		idle.finish(sum)
+		if runStatsOut {
+			if err := stats.print(os.Stderr); err != nil {
+				return err
+			}
+		}
+		return finishRun(cmd, prev, sum)
 	},
 }
 
+// finishRun prints the run summary, if any, and runs the end-of-run checks
+// against the final sample, if one was taken. Streams reach here on ctrl-c
+// as well as on count/duration.
+func finishRun(cmd *cobra.Command, last *Snapshot, sum *summary) error {
+	if err := sum.print(os.Stderr); err != nil {
+		return err
+	}
+	if last == nil {
+		return nil
+	}
+	if breaches := checkThresholds(*last); len(breaches) > 0 {
+		cmd.SilenceUsage = true
+		return &exitError{code: exitThreshold, err: thresholdError(breaches)}
+	}
+	return nil
+}
+
 func init() {
 	rootCmd.AddCommand(collectCmd)
-	collectCmd.Flags().BoolVar(&jsonOut, "json", false, "output JSON instead of table")
-	collectCmd.Flags().DurationVar(&interval, "interval", 0, "sampling interval (e.g. 2s); 0 for single sample")
+	collectCmd.Flags().BoolVar(&jsonOut, "json", false, "output JSON instead of table (same as --format json)")
+	collectCmd.Flags().StringVar(&format, "format", formatHuman, "output format: "+strings.Join(formats, ", "))
+	collectCmd.Flags().DurationVar(&interval, "interval", 0, "sampling interval (e.g. 2s); the first sample is taken immediately. 0 for a single sample")
 	collectCmd.Flags().IntVar(&count, "count", 1, "number of samples when using --interval (0 = infinite)")
+	collectCmd.Flags().DurationVar(&duration, "duration", 0, "stop streaming after this much time (e.g. 5m); whichever of --count/--duration is hit first ends the run")
+	collectCmd.Flags().StringArrayVar(&diskPaths, "disk-path", nil, "path to report disk usage for (repeatable; default is the root filesystem)")
+	collectCmd.Flags().StringSliceVar(&onlyGroups, "only", nil, "collect only these metric groups (comma-separated: cpu,load,mem,disk,net)")
+	collectCmd.Flags().BoolVar(&noCPU, "no-cpu", false, "skip CPU collection")
+	collectCmd.Flags().BoolVar(&noLoad, "no-load", false, "skip load average collection")
+	collectCmd.Flags().BoolVar(&noMem, "no-mem", false, "skip memory collection")
+	collectCmd.Flags().BoolVar(&noDisk, "no-disk", false, "skip disk usage collection")
+	collectCmd.Flags().BoolVar(&noNet, "no-net", false, "skip network collection")
+	collectCmd.Flags().BoolVar(&diskIO, "disk-io", false, "collect per-device disk I/O counters and rates")
+	collectCmd.Flags().BoolVar(&perNIC, "per-nic", false, "also report per-interface network counters")
+	collectCmd.Flags().StringArrayVar(&nics, "nic", nil, "only report these interfaces in per-NIC stats (repeatable; implies --per-nic)")
+	collectCmd.Flags().BoolVar(&perCPU, "per-cpu", false, "also report per-core CPU percentages")
+	collectCmd.Flags().BoolVar(&verbose, "verbose", false, "add kernel version and boot time to human output")
+	collectCmd.Flags().BoolVar(&warmup, "warmup", false, "take and discard one sample first so rates are reported from the first emitted sample")
+	collectCmd.Flags().DurationVar(&cpuWindow, "cpu-window", 200*time.Millisecond, "how long to measure CPU percent over in each sample")
+	collectCmd.Flags().BoolVar(&align, "align", false, "start streaming on the next wall-clock multiple of --interval and tick from there")
+	collectCmd.Flags().StringVar(&hostname, "hostname", "", "report this host name instead of the system's (default $HOSTNAME if set)")
 }
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestFormatUptime(t *testing.T) {
+	tests := []struct {
+		seconds uint64
+		want    string
+	}{
+		{0, "0s"},
+		{45, "45s"},
+		{59, "59s"},
+		{60, "1m"},
+		{3600, "1h"},
+		{3660, "1h1m"},
+		{86400, "1d"},
+		{90061, "1d1h1m"},
+		{273120, "3d3h52m"},
+	}
+	for _, tt := range tests {
+		if got := FormatUptime(tt.seconds); got != tt.want {
+			t.Errorf("FormatUptime(%d) = %q, want %q", tt.seconds, got, tt.want)
+		}
+	}
+}
+
+func TestRunCollectorsSkipsUnsupported(t *testing.T) {
+	oldFailFast := failFast
+	t.Cleanup(func() { failFast = oldFailFast })
+	failFast = true
+
+	pct := 87.0
+	cs := []collector{
+		{"battery", func(context.Context) (func(*Snapshot), error) { return nil, errUnsupported }},
+		{"fds", func(context.Context) (func(*Snapshot), error) { return nil, fmt.Errorf("read fds: %w", errUnsupported) }},
+		{"mem", func(context.Context) (func(*Snapshot), error) {
+			return func(s *Snapshot) { s.MemUsedPct = &pct }, nil
+		}},
+	}
+	var snap Snapshot
+	if failed := runCollectors(context.Background(), cs, &snap); failed != 0 {
+		t.Errorf("runCollectors() failed = %d, want 0", failed)
+	}
+	if snap.Errors != nil {
+		t.Errorf("snap.Errors = %v, want none", snap.Errors)
+	}
+	if snap.MemUsedPct == nil || *snap.MemUsedPct != pct {
+		t.Errorf("mem result not applied: %v", snap.MemUsedPct)
+	}
+	if _, stop := collectFailure(&cobra.Command{}, snap, nil, false); stop != nil {
+		t.Errorf("--fail-fast stopped on an unsupported metric: %v", stop)
+	}
+
+	// A real failure still counts and still stops --fail-fast
+	cs = append(cs, collector{"temps", func(context.Context) (func(*Snapshot), error) { return nil, errors.New("sensor read failed") }})
+	snap = Snapshot{}
+	if failed := runCollectors(context.Background(), cs, &snap); failed != 1 {
+		t.Errorf("runCollectors() failed = %d, want 1", failed)
+	}
+	if len(snap.Errors) != 1 || snap.Errors["temps"] != "sensor read failed" {
+		t.Errorf("snap.Errors = %v, want only temps", snap.Errors)
+	}
+	if _, stop := collectFailure(&cobra.Command{}, snap, nil, false); stop == nil {
+		t.Error("--fail-fast did not stop on a failed collector")
+	}
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+)
+
+var (
+	colorMode string
+
+	// useColor is resolved from --color once per run. Emitters writing to
+	// a file pass color=false to the table helpers instead of changing it.
+	useColor bool
+)
+
+// Every cell of a colorable column (headers included) is wrapped, so the
+// table pads by visible width only; see cellWidth.
+const (
+	ansiDefault = "\x1b[39m"
+	ansiYellow  = "\x1b[33m"
+	ansiRed     = "\x1b[31m"
+	ansiReset   = "\x1b[0m"
+)
+
+// resolveColor decides whether the human table is colored. "auto" colors
+// only when writing to a terminal and NO_COLOR is unset.
+func resolveColor() error {
+	switch colorMode {
+	case "always":
+		useColor = true
+	case "never":
+		useColor = false
+	case "auto":
+		_, noColor := os.LookupEnv("NO_COLOR")
+		useColor = !noColor && (outputPath == "" || outputFormat != "") && isTerminal(os.Stdout)
+	default:
+		return fmt.Errorf("unknown --color %q (want auto, always or never)", colorMode)
+	}
+	return nil
+}
+
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// colorPct wraps a formatted percentage cell in yellow above 70 and red
+// above 90 when color is set.
+func colorPct(cell string, v *float64, color bool) string {
+	if !color {
+		return cell
+	}
+	switch {
+	case v != nil && *v > 90:
+		return ansiRed + cell + ansiReset
+	case v != nil && *v > 70:
+		return ansiYellow + cell + ansiReset
+	}
+	return ansiDefault + cell + ansiReset
+}
+
+// colorHeader wraps the heading of a colorable column to match its cells.
+func colorHeader(h string, color bool) string {
+	if !color {
+		return h
+	}
+	return ansiDefault + h + ansiReset
+}
+
+func init() {
+	collectCmd.Flags().StringVar(&colorMode, "color", "auto", "color threshold cells in the table: auto, always or never (honors NO_COLOR)")
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+var cfgFile string
+
+// applyConfig loads the config file and copies its values onto any flag of
+// cmd that wasn't set on the command line. Keys are the long flag names, e.g.
+// "interval", "format", "disk-path". Precedence is: command-line flags, then
+// the config file, then flag defaults.
+func applyConfig(cmd *cobra.Command) error {
+	v := viper.New()
+	if cfgFile != "" {
+		v.SetConfigFile(cfgFile)
+	} else {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil
+		}
+		def := filepath.Join(home, ".gostats.yaml")
+		if _, err := os.Stat(def); err != nil {
+			return nil // the default location is optional
+		}
+		v.SetConfigFile(def)
+	}
+	if err := v.ReadInConfig(); err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+	src := v.ConfigFileUsed()
+
+	var errs []error
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if f.Changed || !v.IsSet(f.Name) {
+			return
+		}
+		vals := []any{v.Get(f.Name)}
+		if list, ok := vals[0].([]any); ok {
+			vals = list
+		}
+		for _, val := range vals {
+			if err := f.Value.Set(fmt.Sprint(val)); err != nil {
+				errs = append(errs, fmt.Errorf("config file %s: invalid value %q for %q: %v (command-line flags override config file values, which override defaults)", src, fmt.Sprint(val), f.Name, err))
+				return
+			}
+		}
+	})
+	return errors.Join(errs...)
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/shirou/gopsutil/v4/net"
+)
+
+var (
+	connections bool
+	ports       bool
+)
+
+// PortSummary shows which TCP ports are listening and how many established
+// connections each has, to spot a service piling up clients.
+type PortSummary struct {
+	Listening []ListenSocket `json:"listening"`
+	// EstablishedByPort counts inbound connections per listening port;
+	// EstablishedOutbound is everything else (from ephemeral local ports).
+	EstablishedByPort   map[uint32]int `json:"established_by_port,omitempty"`
+	EstablishedOutbound int            `json:"established_outbound"`
+}
+
+// ListenSocket is one bound listening address.
+type ListenSocket struct {
+	Addr string `json:"addr,omitempty"` // left out with --redact
+	Port uint32 `json:"port"`
+}
+
+// tcpStates counts TCP sockets by state (ESTABLISHED, TIME_WAIT, LISTEN...).
+func tcpStates(conns []net.ConnectionStat) map[string]int {
+	states := map[string]int{}
+	for _, c := range conns {
+		states[c.Status]++
+	}
+	return states
+}
+
+// portSummary groups established connections by the listening port they
+// arrived on.
+func portSummary(conns []net.ConnectionStat) *PortSummary {
+	ps := &PortSummary{EstablishedByPort: map[uint32]int{}}
+	listening := map[uint32]bool{}
+	for _, c := range conns {
+		if c.Status == "LISTEN" {
+			sock := ListenSocket{Addr: c.Laddr.IP, Port: c.Laddr.Port}
+			if !slices.Contains(ps.Listening, sock) {
+				ps.Listening = append(ps.Listening, sock)
+			}
+			listening[c.Laddr.Port] = true
+		}
+	}
+	for _, c := range conns {
+		if c.Status != "ESTABLISHED" {
+			continue
+		}
+		if listening[c.Laddr.Port] {
+			ps.EstablishedByPort[c.Laddr.Port]++
+		} else {
+			ps.EstablishedOutbound++
+		}
+	}
+	slices.SortFunc(ps.Listening, func(a, b ListenSocket) int {
+		return cmp.Or(cmp.Compare(a.Port, b.Port), cmp.Compare(a.Addr, b.Addr))
+	})
+	return ps
+}
+
+// collectConnections walks the TCP socket table once for both --connections
+// and --ports.
+func collectConnections(ctx context.Context) (func(*Snapshot), error) {
+	conns, err := net.ConnectionsWithContext(ctx, "tcp")
+	if err != nil {
+		return nil, err
+	}
+	return func(s *Snapshot) {
+		if connections {
+			s.TCPStates = tcpStates(conns)
+		}
+		if ports {
+			s.Ports = portSummary(conns)
+		}
+	}, nil
+}
+
+func init() {
+	collectCmd.Flags().BoolVar(&connections, "connections", false, "count TCP connections by state (can be expensive on busy hosts)")
+	collectCmd.Flags().BoolVar(&ports, "ports", false, "list listening TCP ports and count established connections per port")
+}
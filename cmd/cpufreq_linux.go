@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/shirou/gopsutil/v4/cpu"
+)
+
+// readCurMHz averages scaling_cur_freq over the CPUs under cpufreq. Without
+// cpufreq (most VMs) it falls back to the "cpu MHz" lines of /proc/cpuinfo.
+func readCurMHz() (float64, error) {
+	if mhz, ok := sysfsMHz("scaling_cur_freq", false); ok {
+		return mhz, nil
+	}
+	f, err := os.Open("/proc/cpuinfo")
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	var sum float64
+	n := 0
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		k, v, ok := strings.Cut(sc.Text(), ":")
+		if !ok || strings.TrimSpace(k) != "cpu MHz" {
+			continue
+		}
+		if mhz, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+			sum += mhz
+			n++
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return 0, err
+	}
+	if n == 0 {
+		return 0, errUnsupported
+	}
+	return sum / float64(n), nil
+}
+
+// readMaxMHz is the highest cpuinfo_max_freq of any CPU. gopsutil's Mhz is
+// only that when cpufreq is present and the current clock otherwise, so it
+// isn't used here.
+func readMaxMHz([]cpu.InfoStat) *float64 {
+	if mhz, ok := sysfsMHz("cpuinfo_max_freq", true); ok {
+		return &mhz
+	}
+	return nil
+}
+
+// sysfsMHz reads a cpufreq file (in kHz) for every CPU and returns the
+// average, or the maximum with highest set.
+func sysfsMHz(name string, highest bool) (float64, bool) {
+	files, _ := filepath.Glob("/sys/devices/system/cpu/cpu[0-9]*/cpufreq/" + name)
+	var sum, top float64
+	n := 0
+	for _, path := range files {
+		s, err := readSysFile(filepath.Dir(path), name)
+		if err != nil {
+			continue
+		}
+		khz, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			continue
+		}
+		sum += khz / 1000
+		top = max(top, khz/1000)
+		n++
+	}
+	if n == 0 {
+		return 0, false
+	}
+	if highest {
+		return top, true
+	}
+	return sum / float64(n), true
+}
@@ -0,0 +1,21 @@
+//go:build !linux
+
+package cmd
+
+import "github.com/shirou/gopsutil/v4/cpu"
+
+func readCurMHz() (float64, error) {
+	return 0, errUnsupported
+}
+
+// readMaxMHz takes the rated clock gopsutil reports from sysctl or WMI.
+func readMaxMHz(infos []cpu.InfoStat) *float64 {
+	var top float64
+	for _, in := range infos {
+		top = max(top, in.Mhz)
+	}
+	if top == 0 {
+		return nil
+	}
+	return &top
+}
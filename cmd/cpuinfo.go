@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/shirou/gopsutil/v4/cpu"
+)
+
+var cpuInfo bool
+
+// CPUInfo describes the processors: the model, and each socket's model on
+// multi-socket machines whose sockets differ. CurMHz is the current clock
+// averaged over all logical CPUs and is read every sample, since frequency
+// scaling moves it; MaxMHz is the highest rated clock. Readings the
+// platform doesn't expose are left out.
+type CPUInfo struct {
+	Model   string      `json:"model,omitempty"`
+	Sockets []CPUSocket `json:"sockets,omitempty"`
+	MaxMHz  *float64    `json:"max_mhz,omitempty"`
+	CurMHz  *float64    `json:"cur_mhz,omitempty"`
+}
+
+// CPUSocket is the model of one physical package.
+type CPUSocket struct {
+	ID      string `json:"id"`
+	Model   string `json:"model"`
+	CacheKB int32  `json:"cache_kb,omitempty"`
+}
+
+var (
+	cpuStaticMu sync.Mutex
+	cpuStatic   *CPUInfo
+)
+
+// staticCPUInfo reads the parts of CPUInfo that don't change and caches
+// them once a read succeeds. A failed read, such as one cut short by
+// --collect-timeout, is tried again on the next sample.
+func staticCPUInfo(ctx context.Context) (CPUInfo, error) {
+	cpuStaticMu.Lock()
+	defer cpuStaticMu.Unlock()
+	if cpuStatic != nil {
+		return *cpuStatic, nil
+	}
+	infos, err := cpu.InfoWithContext(ctx)
+	if err != nil && len(infos) == 0 {
+		return CPUInfo{}, err
+	}
+	ci := cpuInfoFrom(infos)
+	ci.MaxMHz = readMaxMHz(infos)
+	cpuStatic = &ci
+	return ci, nil
+}
+
+// cpuInfoFrom groups gopsutil's entries, one per logical CPU on Linux and
+// one per package elsewhere, by socket. Sockets are only listed when their
+// models differ; otherwise Model alone says it all.
+func cpuInfoFrom(infos []cpu.InfoStat) CPUInfo {
+	var ci CPUInfo
+	var sockets []CPUSocket
+	for _, in := range infos {
+		id := in.PhysicalID
+		if id == "" {
+			id = "0"
+		}
+		if slices.ContainsFunc(sockets, func(s CPUSocket) bool { return s.ID == id }) {
+			continue
+		}
+		sockets = append(sockets, CPUSocket{ID: id, Model: in.ModelName, CacheKB: in.CacheSize})
+	}
+	if len(sockets) == 0 {
+		return ci
+	}
+	ci.Model = sockets[0].Model
+	for _, s := range sockets[1:] {
+		if s.Model != ci.Model {
+			ci.Sockets = sockets
+			break
+		}
+	}
+	return ci
+}
+
+// readCPUInfo is the --cpu-info collector: the cached static facts plus the
+// current frequency, where the platform reports one.
+func readCPUInfo(ctx context.Context) (*CPUInfo, error) {
+	ci, err := staticCPUInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cur, err := readCurMHz()
+	switch {
+	case err == nil:
+		ci.CurMHz = &cur
+	case !errors.Is(err, errUnsupported):
+		return nil, err
+	}
+	return &ci, nil
+}
+
+// humanCPUMHz renders the MHZ column as the current clock.
+func (s Snapshot) humanCPUMHz() string {
+	if s.CPUInfo == nil {
+		return "-"
+	}
+	return optFloat(s.CPUInfo.CurMHz, 0)
+}
+
+func init() {
+	collectCmd.Flags().BoolVar(&cpuInfo, "cpu-info", false, "report the CPU model (per socket where they differ) with its current and maximum clock in MHz")
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/shirou/gopsutil/v4/cpu"
+)
+
+// logicalCPUs repeats one /proc/cpuinfo entry per logical CPU, the way
+// gopsutil reports them on Linux.
+func logicalCPUs(n int, physicalID, model string, cacheKB int32) []cpu.InfoStat {
+	return slices.Repeat([]cpu.InfoStat{{PhysicalID: physicalID, ModelName: model, CacheSize: cacheKB}}, n)
+}
+
+func TestCPUInfoFromIdenticalSockets(t *testing.T) {
+	const xeon = "Intel(R) Xeon(R) Gold 6230 CPU @ 2.10GHz"
+	infos := append(logicalCPUs(40, "0", xeon, 28160), logicalCPUs(40, "1", xeon, 28160)...)
+	got := cpuInfoFrom(infos)
+	if got.Model != xeon {
+		t.Errorf("Model = %q, want %q", got.Model, xeon)
+	}
+	if got.Sockets != nil {
+		t.Errorf("Sockets = %+v, want none when every socket has the same model", got.Sockets)
+	}
+}
+
+func TestCPUInfoFromMixedSockets(t *testing.T) {
+	const (
+		gold   = "Intel(R) Xeon(R) Gold 6230 CPU @ 2.10GHz"
+		silver = "Intel(R) Xeon(R) Silver 4210 CPU @ 2.20GHz"
+	)
+	// A board repaired with a different part; socket order follows the
+	// first CPU of each package, not the IDs
+	infos := append(logicalCPUs(2, "1", silver, 14080), logicalCPUs(2, "0", gold, 28160)...)
+	want := CPUInfo{Model: silver, Sockets: []CPUSocket{
+		{ID: "1", Model: silver, CacheKB: 14080},
+		{ID: "0", Model: gold, CacheKB: 28160},
+	}}
+	if got := cpuInfoFrom(infos); !reflect.DeepEqual(got, want) {
+		t.Errorf("cpuInfoFrom() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCPUInfoFromWithoutPhysicalID(t *testing.T) {
+	// macOS and Windows give one entry per package and no physical id;
+	// arm64 Linux often leaves the model name empty as well
+	if got := cpuInfoFrom([]cpu.InfoStat{{ModelName: "Apple M2 Pro"}}); got.Model != "Apple M2 Pro" || got.Sockets != nil {
+		t.Errorf("cpuInfoFrom(Apple M2 Pro) = %+v", got)
+	}
+	if got := cpuInfoFrom(logicalCPUs(4, "", "", 0)); !reflect.DeepEqual(got, CPUInfo{}) {
+		t.Errorf("cpuInfoFrom(no model) = %+v, want zero CPUInfo", got)
+	}
+	if got := cpuInfoFrom(nil); !reflect.DeepEqual(got, CPUInfo{}) {
+		t.Errorf("cpuInfoFrom(nil) = %+v, want zero CPUInfo", got)
+	}
+}
+
+func TestCPUInfoFromThisHost(t *testing.T) {
+	infos, err := cpu.Info()
+	if err != nil || len(infos) == 0 {
+		t.Skipf("no CPU info on this host: %v", err)
+	}
+	got := cpuInfoFrom(infos)
+	if got.Model != infos[0].ModelName {
+		t.Errorf("Model = %q, want the first CPU's %q", got.Model, infos[0].ModelName)
+	}
+	for _, s := range got.Sockets {
+		if s.Model == "" {
+			t.Errorf("socket %s listed without a model", s.ID)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"context"
+	"runtime"
+
+	"github.com/shirou/gopsutil/v4/cpu"
+)
+
+var cpuTimes bool
+
+// CPUTimes is the share of CPU time spent in each mode over a sample window,
+// in percent. Modes a platform doesn't report are left nil.
+type CPUTimes struct {
+	User    float64  `json:"user" unit:"percent"`
+	System  float64  `json:"system" unit:"percent"`
+	Idle    float64  `json:"idle" unit:"percent"`
+	Iowait  *float64 `json:"iowait,omitempty" unit:"percent"`
+	Irq     *float64 `json:"irq,omitempty" unit:"percent"`
+	Softirq *float64 `json:"softirq,omitempty" unit:"percent"`
+	Steal   *float64 `json:"steal,omitempty" unit:"percent"`
+}
+
+// readCPUTimes returns the aggregate cumulative CPU times.
+func readCPUTimes(ctx context.Context) *cpu.TimesStat {
+	ts, err := cpu.TimesWithContext(ctx, false)
+	if err != nil || len(ts) == 0 {
+		return nil
+	}
+	return &ts[0]
+}
+
+// cpuTimesPct converts two cumulative readings into per-mode percentages.
+func cpuTimesPct(a, b cpu.TimesStat) *CPUTimes {
+	// Guest time is already included in user on Linux, so leave it out
+	total := func(t cpu.TimesStat) float64 {
+		return t.User + t.System + t.Idle + t.Nice + t.Iowait + t.Irq + t.Softirq + t.Steal
+	}
+	d := total(b) - total(a)
+	if d <= 0 {
+		return nil
+	}
+	pct := func(x, y float64) float64 { return (y - x) / d * 100 }
+
+	ct := &CPUTimes{
+		User:   pct(a.User, b.User),
+		System: pct(a.System, b.System),
+		Idle:   pct(a.Idle, b.Idle),
+	}
+	switch runtime.GOOS {
+	case "linux":
+		ct.Iowait = ptr(pct(a.Iowait, b.Iowait))
+		ct.Irq = ptr(pct(a.Irq, b.Irq))
+		ct.Softirq = ptr(pct(a.Softirq, b.Softirq))
+		ct.Steal = ptr(pct(a.Steal, b.Steal))
+	case "windows":
+		ct.Irq = ptr(pct(a.Irq, b.Irq))
+	}
+	return ct
+}
+
+func init() {
+	collectCmd.Flags().BoolVar(&cpuTimes, "cpu-times", false, "report CPU time breakdown by mode (user/system/idle/iowait/...)")
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"strconv"
+	"time"
+)
+
+// csvHeader returns the CSV column names. The order is stable and documented
+// in the README; per-disk columns follow the order of --disk-path. Per-core
+// CPU values are not included since the core count isn't known up front.
+func csvHeader() []string {
+	h := []string{
+		"ts", "host", "os", "uptime_sec",
+		"cpu_percent", "load1", "load5", "load15",
+		"mem_used_mb", "mem_total_mb", "mem_used_pct",
+	}
+	for _, p := range diskTargets() {
+		h = append(h,
+			"disk_used_gb["+p+"]",
+			"disk_total_gb["+p+"]",
+			"disk_used_pct["+p+"]")
+	}
+	return append(h,
+		"net_bytes_in", "net_bytes_out",
+		"net_rate_in_bps", "net_rate_out_bps")
+}
+
+// csvRecord returns s as raw values in csvHeader order. Missing optional
+// values and skipped metric groups are written as empty cells.
+func (s Snapshot) csvRecord() []string {
+	r := []string{
+		formatTimestamp(s.Timestamp, time.RFC3339),
+		s.Host,
+		s.OS,
+		strconv.FormatUint(s.UptimeSec, 10),
+		csvOptFloat(s.CPUPercent),
+		csvOptFloat(s.Load1),
+		csvOptFloat(s.Load5),
+		csvOptFloat(s.Load15),
+		csvOptUint(s.MemUsedMB),
+		csvOptUint(s.MemTotalMB),
+		csvOptFloat(s.MemUsedPct),
+	}
+	for _, p := range diskTargets() {
+		used, total, pct := "", "", ""
+		for _, d := range s.Disks {
+			if d.Path == p {
+				used, total, pct = csvFloat(d.UsedGB), csvFloat(d.TotalGB), csvFloat(d.UsedPct)
+				break
+			}
+		}
+		r = append(r, used, total, pct)
+	}
+	return append(r,
+		csvOptUint(s.NetBytesIn),
+		csvOptUint(s.NetBytesOut),
+		csvOptFloat(s.NetRateInBps),
+		csvOptFloat(s.NetRateOutBps))
+}
+
+func csvFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func csvOptFloat(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return csvFloat(*v)
+}
+
+func csvOptUint(v *uint64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatUint(*v, 10)
+}
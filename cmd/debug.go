@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"time"
+)
+
+var debugAddr string
+
+// startedAt is when the process started, for the /debug/stats uptime.
+var startedAt = time.Now()
+
+// startDebugServer serves net/http/pprof and a plain-text page of gostats'
+// own runtime stats on --debug-addr. It only listens on a loopback address:
+// profiles and heap dumps aren't for the network at large.
+func startDebugServer() error {
+	if debugAddr == "" {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(debugAddr)
+	if err != nil {
+		return fmt.Errorf("invalid --debug-addr %q: %w", debugAddr, err)
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return fmt.Errorf("--debug-addr must be a loopback address such as localhost:6060, got %q", debugAddr)
+	}
+	lis, err := net.Listen("tcp", debugAddr)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc("/debug/stats", debugStatsHandler)
+	go func() {
+		if err := http.Serve(lis, mux); err != nil {
+			slog.Warn("debug server stopped", "err", err)
+		}
+	}()
+	slog.Info("debug server listening", "addr", lis.Addr().String())
+	return nil
+}
+
+func debugStatsHandler(w http.ResponseWriter, r *http.Request) {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	var lastPause time.Duration
+	if m.NumGC > 0 {
+		lastPause = time.Duration(m.PauseNs[(m.NumGC+255)%256])
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintf(w, "uptime          %s\n", time.Since(startedAt).Round(time.Second))
+	fmt.Fprintf(w, "goroutines      %d\n", runtime.NumGoroutine())
+	fmt.Fprintf(w, "heap_alloc_mb   %.1f\n", float64(m.HeapAlloc)/(1024*1024))
+	fmt.Fprintf(w, "heap_sys_mb     %.1f\n", float64(m.HeapSys)/(1024*1024))
+	fmt.Fprintf(w, "heap_objects    %d\n", m.HeapObjects)
+	fmt.Fprintf(w, "sys_mb          %.1f\n", float64(m.Sys)/(1024*1024))
+	fmt.Fprintf(w, "gc_cycles       %d\n", m.NumGC)
+	fmt.Fprintf(w, "gc_pause_last   %s\n", lastPause)
+	fmt.Fprintf(w, "gc_pause_total  %s\n", time.Duration(m.PauseTotalNs))
+	fmt.Fprintf(w, "\nProfiles: /debug/pprof/\n")
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&debugAddr, "debug-addr", "", "serve pprof and gostats' own runtime stats on this loopback address (e.g. localhost:6060)")
+}
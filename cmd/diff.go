@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// readSnapshotFile decodes the first snapshot in path, so both a single
+// pretty-printed sample and a stream of JSON lines can be compared.
+func readSnapshotFile(path string) (Snapshot, error) {
+	var s Snapshot
+	f, err := os.Open(path)
+	if err != nil {
+		return s, err
+	}
+	defer f.Close()
+	if err := json.NewDecoder(f).Decode(&s); err != nil {
+		return s, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// writeDiff prints every metric present in either snapshot with its value
+// on each side and the change from a to b. A metric missing on one side
+// shows "-" there and has no delta.
+func writeDiff(w io.Writer, a, b Snapshot) error {
+	av, bv := map[string]float64{}, map[string]float64{}
+	var order []string
+	for _, mv := range metricValues(a) {
+		av[mv.Name] = mv.Value
+		order = append(order, mv.Name)
+	}
+	for _, mv := range metricValues(b) {
+		if _, ok := av[mv.Name]; !ok {
+			order = append(order, mv.Name)
+		}
+		bv[mv.Name] = mv.Value
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "METRIC\tA\tB\tDELTA\tDELTA%")
+	for _, name := range order {
+		x, inA := av[name]
+		y, inB := bv[name]
+		cells := []string{"-", "-", "-", "-"}
+		if inA {
+			cells[0] = fmt.Sprintf("%.2f", x)
+		}
+		if inB {
+			cells[1] = fmt.Sprintf("%.2f", y)
+		}
+		if inA && inB {
+			cells[2] = fmt.Sprintf("%+.2f", y-x)
+			if x != 0 {
+				cells[3] = fmt.Sprintf("%+.1f%%", (y-x)/x*100)
+			}
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", name, cells[0], cells[1], cells[2], cells[3])
+	}
+	return tw.Flush()
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff A.json B.json",
+	Short: "Compare two snapshots saved with collect --json",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		a, err := readSnapshotFile(args[0])
+		if err != nil {
+			return err
+		}
+		b, err := readSnapshotFile(args[1])
+		if err != nil {
+			return err
+		}
+		return writeDiff(os.Stdout, a, b)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+}
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+var dryRun bool
+
+// dryRunDialTimeout bounds each reachability check, so a dry run against a
+// black-holed address still answers quickly.
+const dryRunDialTimeout = 2 * time.Second
+
+// extraCollectors are the opt-in collector flags --dry-run lists when set.
+var extraCollectors = []string{
+	"per-cpu", "cpu-times", "cpu-info", "disk-io", "per-nic", "temps", "users", "procs", "cgroup",
+	"battery", "gpu", "connections", "ports", "fds", "kernel-stats", "psi", "cloud",
+}
+
+// dryRunReport prints the resolved configuration of a collect run and checks
+// what can be checked cheaply: that disk paths exist and that network
+// destinations accept a TCP connection. It returns an error naming how many
+// problems it found, so a script can gate on the exit status.
+func dryRunReport(ctx context.Context, w io.Writer, flags *pflag.FlagSet, outFmt string) error {
+	var problems int
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	row := func(key, val string) { fmt.Fprintf(tw, "%s\t%s\n", key, val) }
+	check := func(key, val string, err error) {
+		if err != nil {
+			problems++
+			val += "  PROBLEM: " + err.Error()
+		} else {
+			val += "  ok"
+		}
+		row(key, val)
+	}
+
+	var groups []string
+	for _, g := range allGroups {
+		if groupEnabled(g) {
+			groups = append(groups, g)
+		}
+	}
+	row("groups", orNone(strings.Join(groups, ", ")))
+	var extras []string
+	for _, name := range extraCollectors {
+		if f := flags.Lookup(name); f != nil && f.Value.String() == "true" {
+			extras = append(extras, name)
+		}
+	}
+	if topN > 0 {
+		extras = append(extras, fmt.Sprintf("top %d by %s", topN, topSort))
+	}
+	row("extras", orNone(strings.Join(extras, ", ")))
+	for _, c := range execCmds {
+		row("exec", c)
+	}
+
+	if groupEnabled(groupDisk) {
+		for _, p := range diskTargets() {
+			check("disk path", p, checkDiskPath(ctx, p))
+		}
+	}
+
+	switch {
+	case interval <= 0:
+		row("mode", "single sample")
+	default:
+		mode := "stream every " + interval.String()
+		if count > 0 {
+			mode += fmt.Sprintf(", %d samples", count)
+		}
+		if duration > 0 {
+			mode += ", for " + duration.String()
+		}
+		if jitter > 0 {
+			mode += ", jitter ±" + jitter.String()
+		}
+		if stopWhenIdle != "" {
+			mode += ", until idle (" + stopWhenIdle + " for " + idleFor.String() + ")"
+		}
+		if count <= 0 && duration <= 0 && stopWhenIdle == "" {
+			mode += ", until interrupted"
+		}
+		row("mode", mode)
+	}
+	if subsamples > 1 {
+		row("cpu window", fmt.Sprintf("%s, average of %d sub-samples", cpuWindow, subsamples))
+	} else {
+		row("cpu window", cpuWindow.String())
+	}
+	row("format", outFmt)
+	switch {
+	case outputPath == "":
+		row("output", "stdout")
+	case outputFormat != "":
+		row("output", "stdout")
+		check("output", outputPath+" ("+outputFormat+")", parentDirOK(outputPath))
+	default:
+		check("output", outputPath, parentDirOK(outputPath))
+	}
+
+	if sshTarget != "" {
+		_, addr := parseSSHTarget(sshTarget)
+		check("ssh", sshTarget, dialCheck(ctx, addr))
+	}
+	for _, u := range []struct{ name, url string }{
+		{"push-url", pushURL}, {"alert-webhook", alertWebhook}, {"slack-webhook", slackWebhook}, {"mqtt-broker", mqttBroker},
+	} {
+		if u.url != "" {
+			check(u.name, u.url, dialURL(ctx, u.url))
+		}
+	}
+	for _, b := range kafkaBrokers {
+		check("kafka-brokers", b, dialCheck(ctx, b))
+	}
+	if otlpEndpoint != "" {
+		check("otlp-endpoint", otlpEndpoint, dialCheck(ctx, otlpEndpoint))
+	}
+	if statsdAddr != "" {
+		row("statsd", statsdAddr+"  (UDP, not checked)")
+	}
+	if syslogAddr != "" {
+		network, addr, err := parseSyslogAddr(syslogAddr)
+		switch {
+		case err != nil:
+			check("syslog", syslogAddr, err)
+		case network == "tcp":
+			check("syslog", syslogAddr, dialCheck(ctx, addr))
+		default:
+			row("syslog", addr+"  (UDP, not checked)")
+		}
+	} else if syslogLocal {
+		conn, err := dialLocalSyslog()
+		if err == nil {
+			conn.Close()
+		}
+		check("syslog", "local socket", err)
+	}
+	if sqlitePath != "" {
+		check("sqlite", sqlitePath, parentDirOK(sqlitePath))
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if problems > 0 {
+		return fmt.Errorf("dry run found %d problem(s)", problems)
+	}
+	return nil
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
+
+// parentDirOK checks whether the directory a file would be created in exists.
+func parentDirOK(path string) error {
+	fi, err := os.Stat(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	if !fi.IsDir() {
+		return fmt.Errorf("%s is not a directory", filepath.Dir(path))
+	}
+	return nil
+}
+
+// dialURL checks that the host of a URL accepts TCP connections, using the
+// scheme's usual port when none is given.
+func dialURL(ctx context.Context, raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if u.Host == "" {
+		return fmt.Errorf("no host in URL")
+	}
+	port := u.Port()
+	if port == "" {
+		port = map[string]string{"http": "80", "https": "443", "ws": "80", "wss": "443", "tcp": "1883", "mqtt": "1883", "ssl": "8883", "tls": "8883", "mqtts": "8883"}[u.Scheme]
+	}
+	if port == "" {
+		return fmt.Errorf("no port in URL")
+	}
+	return dialCheck(ctx, net.JoinHostPort(u.Hostname(), port))
+}
+
+func dialCheck(ctx context.Context, addr string) error {
+	ctx, cancel := context.WithTimeout(ctx, dryRunDialTimeout)
+	defer cancel()
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func init() {
+	collectCmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the resolved configuration, check disk paths and destinations are reachable, and exit without collecting")
+}
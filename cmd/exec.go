@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+var (
+	execCmds    []string
+	execTimeout time.Duration
+)
+
+// execWaitDelay is how long a timed-out --exec command's output pipes may
+// stay open after it is killed, for a grandchild that inherited them.
+const execWaitDelay = time.Second
+
+// execCollector runs one --exec command per sample and merges the JSON
+// object it prints into Snapshot.Custom. Numbers are kept as is and
+// booleans become 1 or 0; any other value is skipped.
+func execCollector(command string) collector {
+	return collector{"exec:" + command, func(ctx context.Context) (func(*Snapshot), error) {
+		vals, err := runExec(ctx, command)
+		if err != nil {
+			return nil, err
+		}
+		return func(s *Snapshot) {
+			if s.Custom == nil {
+				s.Custom = make(map[string]float64, len(vals))
+			}
+			for k, v := range vals {
+				s.Custom[k] = v
+			}
+		}, nil
+	}}
+}
+
+// runExec runs command through the shell, bounded by --exec-timeout within
+// the collection's own deadline.
+func runExec(ctx context.Context, command string) (map[string]float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, execTimeout)
+	defer cancel()
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		c = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	c.WaitDelay = execWaitDelay
+	out, err := c.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("timed out after %s", execTimeout)
+	}
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok && len(ee.Stderr) > 0 {
+			return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(string(ee.Stderr)))
+		}
+		return nil, err
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(out, &raw); err != nil {
+		return nil, fmt.Errorf("output is not a JSON object: %w", err)
+	}
+	vals := make(map[string]float64, len(raw))
+	for k, v := range raw {
+		switch v := v.(type) {
+		case float64:
+			vals[k] = v
+		case bool:
+			vals[k] = 0
+			if v {
+				vals[k] = 1
+			}
+		default:
+			slog.Debug("skipping non-numeric --exec value", "command", command, "key", k)
+		}
+	}
+	return vals, nil
+}
+
+func init() {
+	collectCmd.Flags().StringArrayVar(&execCmds, "exec", nil, "run this command each sample and merge the JSON object of numbers it prints into the custom map (repeatable)")
+	collectCmd.Flags().DurationVar(&execTimeout, "exec-timeout", 5*time.Second, "kill an --exec command that runs longer than this")
+}
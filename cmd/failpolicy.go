@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	failFast  bool
+	keepGoing bool
+)
+
+// resolveFailPolicy settles --fail-fast against --keep-going; passing
+// --keep-going=false is the same as --fail-fast.
+func resolveFailPolicy(cmd *cobra.Command) error {
+	if failFast && cmd.Flags().Changed("keep-going") && keepGoing {
+		return fmt.Errorf("--fail-fast conflicts with --keep-going")
+	}
+	failFast = failFast || !keepGoing
+	return nil
+}
+
+// collectFailure applies the error policy to one collection. It returns a
+// non-nil stop error, exiting with exitCollect, when the run must end, and
+// skip when there is nothing to emit because every collector failed but a
+// stream can carry on with the next tick. Under --keep-going a partly
+// failed sample is emitted as is, with the failures in its errors map.
+func collectFailure(cmd *cobra.Command, snap Snapshot, err error, streaming bool) (skip bool, stop error) {
+	if err == nil && (!failFast || len(snap.Errors) == 0) {
+		return false, nil
+	}
+	if err == nil {
+		err = collectError(snap.Errors)
+	}
+	if streaming && !failFast {
+		slog.Warn("collection failed; skipping this sample", "err", err)
+		return true, nil
+	}
+	cmd.SilenceUsage = true
+	return false, &exitError{code: exitCollect, err: err}
+}
+
+// collectError summarizes the collectors that failed in one cycle.
+func collectError(errs map[string]string) error {
+	parts := make([]string, 0, len(errs))
+	for _, name := range slices.Sorted(maps.Keys(errs)) {
+		parts = append(parts, name+": "+errs[name])
+	}
+	return fmt.Errorf("collection failed: %s", strings.Join(parts, "; "))
+}
+
+func init() {
+	collectCmd.Flags().BoolVar(&failFast, "fail-fast", false, "exit with status 3 as soon as any collector fails")
+	collectCmd.Flags().BoolVar(&keepGoing, "keep-going", true, "log collector failures and carry on with partial data")
+}
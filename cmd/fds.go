@@ -0,0 +1,15 @@
+package cmd
+
+import "errors"
+
+var fds bool
+
+// errUnsupported marks a metric the current platform can't provide; such
+// metrics are simply left out of the snapshot. collectOnce gives them no
+// errors entry and doesn't count them as failures, so --fail-fast ignores
+// them, and --retries doesn't try them again.
+var errUnsupported = errors.New("not supported on this platform")
+
+func init() {
+	collectCmd.Flags().BoolVar(&fds, "fds", false, "report system-wide open file descriptors and the limit (Linux)")
+}
@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+)
+
+// readFDs returns the number of allocated file handles and the system-wide
+// limit from /proc/sys/fs/file-nr ("allocated unused max").
+func readFDs() (open, limit uint64, err error) {
+	b, err := os.ReadFile("/proc/sys/fs/file-nr")
+	if err != nil {
+		return 0, 0, err
+	}
+	var allocated, unused uint64
+	if _, err := fmt.Sscan(string(b), &allocated, &unused, &limit); err != nil {
+		return 0, 0, fmt.Errorf("parsing file-nr: %w", err)
+	}
+	return allocated - unused, limit, nil
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package cmd
+
+// readFDs reports nothing: the system-wide descriptor count is Linux-only.
+func readFDs() (open, limit uint64, err error) {
+	return 0, 0, errUnsupported
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+var fieldList []string
+
+// snapshotFields lists the top-level JSON keys of a Snapshot in the order
+// they are encoded.
+func snapshotFields() []string {
+	t := reflect.TypeOf(Snapshot{})
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if !f.IsExported() || tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = f.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// resolveFields checks --fields against the Snapshot's JSON keys.
+func resolveFields(outFmt, fileFmt string) error {
+	if len(fieldList) == 0 {
+		return nil
+	}
+	if outFmt != formatJSON && fileFmt != formatJSON {
+		return fmt.Errorf("--fields only applies to json output (--format or --output-format json)")
+	}
+	valid := snapshotFields()
+	for _, f := range fieldList {
+		if !slices.Contains(valid, f) {
+			return fmt.Errorf("unknown --fields name %q (valid: %s)", f, strings.Join(valid, ", "))
+		}
+	}
+	return nil
+}
+
+// projectFields encodes s with only the --fields keys, keeping the Snapshot's
+// own key order. Selected fields that weren't collected stay omitted.
+func projectFields(s Snapshot) (json.RawMessage, error) {
+	full, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(full, &m); err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for _, name := range snapshotFields() {
+		v, ok := m[name]
+		if !ok || !slices.Contains(fieldList, name) {
+			continue
+		}
+		if b.Len() > 1 {
+			b.WriteByte(',')
+		}
+		k, _ := json.Marshal(name)
+		b.Write(k)
+		b.WriteByte(':')
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+func init() {
+	collectCmd.Flags().StringSliceVar(&fieldList, "fields", nil, "only output these top-level JSON keys (comma-separated, e.g. ts,cpu_percent,mem_used_pct)")
+}
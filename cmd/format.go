@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+)
+
+const (
+	formatHuman      = "human"
+	formatJSON       = "json"
+	formatCSV        = "csv"
+	formatPrometheus = "prometheus"
+	formatGraphite   = "graphite"
+	formatInflux     = "influx"
+)
+
+var formats = []string{formatHuman, formatJSON, formatCSV, formatPrometheus, formatGraphite, formatInflux}
+
+var (
+	noHeader     bool
+	repeatHeader int
+	prettyJSON   bool
+	compactJSON  bool
+)
+
+// resolveFormat reconciles --json with --format. --json is kept as a
+// shorthand for --format json so existing invocations keep working.
+func resolveFormat() (string, error) {
+	if prettyJSON && compactJSON {
+		return "", fmt.Errorf("--pretty conflicts with --compact")
+	}
+	if jsonOut {
+		if format != formatHuman && format != formatJSON {
+			return "", fmt.Errorf("--json conflicts with --format %s", format)
+		}
+		return formatJSON, nil
+	}
+	if !slices.Contains(formats, format) {
+		return "", fmt.Errorf("unknown --format %q (want %s)", format, strings.Join(formats, ", "))
+	}
+	return format, nil
+}
+
+// emitter renders snapshots in one output format. CSV writes its header
+// once from begin; the human table prints its header along with the first
+// row so both are aligned together. Every sample then goes through emit.
+type emitter struct {
+	w      io.Writer
+	format string
+	stream bool
+	csv    *csv.Writer
+
+	rows   int   // human rows written, for --repeat-header
+	widths []int // human column widths, fixed when the first row goes out
+	plain  bool  // never color, whatever --color says
+}
+
+func newEmitter(w io.Writer, format string, stream bool) *emitter {
+	e := &emitter{w: w, format: format, stream: stream}
+	if format == formatCSV {
+		e.csv = csv.NewWriter(w)
+	}
+	return e
+}
+
+func (e *emitter) begin() error {
+	switch e.format {
+	case formatCSV:
+		// An --output file that is being appended to has its header already
+		if f, ok := e.w.(interface{ resumed() bool }); ok && f.resumed() {
+			return nil
+		}
+		e.csv.Write(csvHeader())
+		e.csv.Flush()
+		return e.csv.Error()
+	}
+	return nil
+}
+
+func (e *emitter) emit(s Snapshot) error {
+	switch e.format {
+	case formatJSON:
+		// A single sample is pretty-printed and streams are one object per
+		// line, unless --pretty or --compact says otherwise.
+		enc := json.NewEncoder(e.w)
+		if (!e.stream || prettyJSON) && !compactJSON {
+			enc.SetIndent("", "  ")
+		}
+		if len(fieldList) > 0 {
+			proj, err := projectFields(s)
+			if err != nil {
+				return err
+			}
+			return enc.Encode(proj)
+		}
+		return enc.Encode(s)
+	case formatCSV:
+		e.csv.Write(s.csvRecord())
+		e.csv.Flush()
+		return e.csv.Error()
+	case formatPrometheus:
+		return writePrometheus(e.w, s)
+	case formatGraphite:
+		return writeGraphite(e.w, s)
+	case formatInflux:
+		return writeInflux(e.w, s)
+	}
+	return e.emitHuman(s)
+}
+
+// headerText is what a fresh output file should start with for this format.
+func (e *emitter) headerText() []byte {
+	switch e.format {
+	case formatCSV:
+		var b bytes.Buffer
+		w := csv.NewWriter(&b)
+		w.Write(csvHeader())
+		w.Flush()
+		return b.Bytes()
+	case formatHuman:
+		if noHeader {
+			return nil
+		}
+		hdr := strings.Split(humanHeader(e.color()), "\t")
+		return []byte(padRow(hdr, e.columnWidths()))
+	}
+	return nil
+}
+
+// color reports whether this emitter's table is colored.
+func (e *emitter) color() bool {
+	return useColor && !e.plain
+}
+
+// emitHuman writes one table row. Column widths are set once, from the
+// header and humanWidths, and every row is padded to them, so a stream stays
+// aligned even though each sample is flushed on its own. A cell that still
+// doesn't fit widens its column from that row on. The header goes out before
+// the first row and then every --repeat-header rows, unless --no-header is
+// set.
+func (e *emitter) emitHuman(s Snapshot) error {
+	color := e.color()
+	hdr := strings.Split(humanHeader(color), "\t")
+	row := strings.Split(s.humanRow(color), "\t")
+	widths := e.columnWidths()
+	for i, c := range row[:min(len(row)-1, len(widths))] {
+		widths[i] = max(widths[i], cellWidth(c))
+	}
+	var b strings.Builder
+	if !noHeader && (e.rows == 0 || repeatHeader > 0 && e.rows%repeatHeader == 0) {
+		b.WriteString(padRow(hdr, widths))
+	}
+	b.WriteString(padRow(row, widths))
+	e.rows++
+	if _, err := io.WriteString(e.w, b.String()); err != nil {
+		return err
+	}
+	if len(s.Processes) > 0 {
+		_, err := io.WriteString(e.w, alignTable(humanProcTable(s.Processes)))
+		return err
+	}
+	return nil
+}
+
+// humanWidths is how wide the cells of a column usually get, for columns
+// whose values outgrow their heading. The rest start at the heading's width.
+var humanWidths = map[string]int{
+	"TIME":              8, // 15:04:05
+	"CPU%":              5, // 100.0
+	"MEM%":              5,
+	"Load1":             6,  // ~12.34
+	"NET_IN/NET_OUT(B)": 25, // up to a terabyte each way
+	"UPTIME":            10, // 365d23h59m
+	"BOOT":              25, // RFC 3339 with a zone offset
+	"MHZ":               4,
+	"PSI_CPU/MEM/IO":    17,
+	"BATT":              5, // 100%+
+}
+
+// columnWidths returns the emitter's column widths, working them out from
+// the header and humanWidths the first time.
+func (e *emitter) columnWidths() []int {
+	if e.widths == nil {
+		hdr := strings.Split(humanHeader(false), "\t")
+		e.widths = make([]int, len(hdr))
+		for i, h := range hdr {
+			e.widths[i] = max(cellWidth(h), humanWidths[h])
+		}
+	}
+	return e.widths
+}
+
+// padRow joins cells with two spaces, padding each but the last to its
+// column's width.
+func padRow(cells []string, widths []int) string {
+	var b strings.Builder
+	for i, c := range cells {
+		b.WriteString(c)
+		if i == len(cells)-1 {
+			break
+		}
+		pad := 2
+		if i < len(widths) {
+			pad += max(widths[i]-cellWidth(c), 0)
+		}
+		b.WriteString(strings.Repeat(" ", pad))
+	}
+	b.WriteByte('\n')
+	return b.String()
+}
+
+// cellWidth is how many columns c takes on screen: ANSI color codes take none.
+func cellWidth(c string) int {
+	n := 0
+	for len(c) > 0 {
+		if strings.HasPrefix(c, "\x1b[") {
+			if end := strings.IndexByte(c, 'm'); end >= 0 {
+				c = c[end+1:]
+				continue
+			}
+		}
+		_, size := utf8.DecodeRuneInString(c)
+		c = c[size:]
+		n++
+	}
+	return n
+}
+
+// alignTable lays out tab-separated lines as space-padded columns.
+func alignTable(lines ...string) string {
+	var b strings.Builder
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	for _, l := range lines {
+		fmt.Fprintln(tw, l)
+	}
+	tw.Flush()
+	return b.String()
+}
+
+func init() {
+	collectCmd.Flags().BoolVar(&noHeader, "no-header", false, "omit the header line from human output")
+	collectCmd.Flags().BoolVar(&prettyJSON, "pretty", false, "indent JSON output, even when streaming")
+	collectCmd.Flags().BoolVar(&compactJSON, "compact", false, "write JSON as one line per sample, even for a single sample")
+	collectCmd.Flags().IntVar(&repeatHeader, "repeat-header", 0, "reprint the human output header every N rows when streaming (0 = only once)")
+}
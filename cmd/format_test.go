@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEmitHumanKeepsColumns(t *testing.T) {
+	oldColor, oldHeader := useColor, repeatHeader
+	t.Cleanup(func() { useColor, repeatHeader = oldColor, oldHeader })
+	useColor, repeatHeader = false, 0
+
+	var b strings.Builder
+	e := newEmitter(&b, formatHuman, true)
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	// Values that get wider as the stream goes on, each still within its
+	// column's usual width
+	for i, v := range []struct {
+		cpu    float64
+		load   float64
+		in     uint64
+		uptime uint64
+	}{
+		{0.5, 0.2, 9, 59},
+		{42.0, 3.75, 123456, 3600},
+		{100, 12.5, 987654321012, 31535940},
+	} {
+		err := e.emit(Snapshot{
+			Timestamp:   at.Add(time.Duration(i) * time.Second),
+			Host:        "db-1",
+			CPUPercent:  ptr(v.cpu),
+			Load1:       ptr(v.load),
+			MemUsedPct:  ptr(v.cpu),
+			NetBytesIn:  ptr(v.in),
+			NetBytesOut: ptr(v.in),
+			UptimeSec:   v.uptime,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("want a header and 3 rows, got:\n%s", b.String())
+	}
+	// Every column starts where its heading does, on every row
+	for _, h := range []string{"CPU%", "Load1", "MEM%", "NET_IN/NET_OUT(B)", "UPTIME", "HOST"} {
+		col := strings.Index(lines[0], h)
+		for _, l := range lines[1:] {
+			if col == 0 || l[col-1] != ' ' || l[col-2] != ' ' || l[col] == ' ' {
+				t.Errorf("%s column starts at %d, but not in\n%s\n%s", h, col, lines[0], l)
+			}
+		}
+	}
+}
+
+func TestEmitHumanPlain(t *testing.T) {
+	old := useColor
+	t.Cleanup(func() { useColor = old })
+	useColor = true
+
+	var screen, file strings.Builder
+	es := emitters{newEmitter(&screen, formatHuman, true), newEmitter(&file, formatHuman, true)}
+	es[1].plain = true
+	if err := es.emit(Snapshot{Host: "db-1", CPUPercent: ptr(95.0)}); err != nil {
+		t.Fatal(err)
+	}
+	if !useColor {
+		t.Error("a plain emitter turned --color off for everyone")
+	}
+	if !strings.Contains(screen.String(), ansiRed+"95.0"+ansiReset) {
+		t.Errorf("terminal row not colored:\n%q", screen.String())
+	}
+	if strings.Contains(file.String(), "\x1b[") {
+		t.Errorf("plain row has color codes:\n%q", file.String())
+	}
+	// Without the codes both tables are the same
+	if got := strings.NewReplacer(ansiRed, "", ansiDefault, "", ansiReset, "").Replace(screen.String()); got != file.String() {
+		t.Errorf("colored table laid out differently:\n%s\nplain:\n%s", got, file.String())
+	}
+}
+
+func TestCellWidth(t *testing.T) {
+	for c, want := range map[string]int{
+		"":                              0,
+		"42.0":                          4,
+		ansiYellow + "75.5" + ansiReset: 4,
+		"c0=12.3 c1=0.0":                14,
+		"sensor=41.0°C":                 13,
+	} {
+		if got := cellWidth(c); got != want {
+			t.Errorf("cellWidth(%q) = %d, want %d", c, got, want)
+		}
+	}
+}
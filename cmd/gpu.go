@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+var gpus bool
+
+// GPUStat is one NVIDIA GPU as reported by nvidia-smi. Readings a card or
+// driver doesn't support ("[N/A]") are left out.
+type GPUStat struct {
+	Index      int      `json:"index"`
+	Name       string   `json:"name"`
+	UtilPct    *float64 `json:"util_pct,omitempty"`
+	MemUsedMB  *uint64  `json:"mem_used_mb,omitempty"`
+	MemTotalMB *uint64  `json:"mem_total_mb,omitempty"`
+	MemUsedPct *float64 `json:"mem_used_pct,omitempty"`
+	TempC      *float64 `json:"temp_c,omitempty" unit:"celsius"`
+}
+
+const gpuQuery = "index,name,utilization.gpu,memory.used,memory.total,temperature.gpu"
+
+// readGPUs queries nvidia-smi, which ships with the driver, so the binary
+// has no build- or load-time dependency on NVML. Without nvidia-smi on the
+// PATH, or without a card, there is nothing to report: errUnsupported.
+func readGPUs(ctx context.Context) ([]GPUStat, error) {
+	bin, err := exec.LookPath("nvidia-smi")
+	if errors.Is(err, exec.ErrNotFound) {
+		return nil, errUnsupported
+	}
+	if err != nil {
+		return nil, err
+	}
+	out, err := exec.CommandContext(ctx, bin, "--query-gpu="+gpuQuery, "--format=csv,noheader,nounits").Output()
+	if err != nil {
+		// A driver install with no card present fails with this message
+		var ee *exec.ExitError
+		if errors.As(err, &ee) && strings.Contains(string(out)+string(ee.Stderr), "No devices were found") {
+			return nil, errUnsupported
+		}
+		return nil, fmt.Errorf("nvidia-smi: %w", err)
+	}
+	return parseGPUs(string(out))
+}
+
+func parseGPUs(out string) ([]GPUStat, error) {
+	r := csv.NewReader(strings.NewReader(out))
+	r.TrimLeadingSpace = true
+	recs, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("nvidia-smi: %w", err)
+	}
+	var stats []GPUStat
+	for _, rec := range recs {
+		if len(rec) != 6 {
+			return nil, fmt.Errorf("nvidia-smi: unexpected row %q", rec)
+		}
+		idx, err := strconv.Atoi(rec[0])
+		if err != nil {
+			return nil, fmt.Errorf("nvidia-smi: bad GPU index %q", rec[0])
+		}
+		g := GPUStat{
+			Index:      idx,
+			Name:       rec[1],
+			UtilPct:    smiFloat(rec[2]),
+			MemUsedMB:  smiUint(rec[3]),
+			MemTotalMB: smiUint(rec[4]),
+			TempC:      smiFloat(rec[5]),
+		}
+		if g.MemUsedMB != nil && g.MemTotalMB != nil && *g.MemTotalMB > 0 {
+			g.MemUsedPct = ptr(float64(*g.MemUsedMB) / float64(*g.MemTotalMB) * 100)
+		}
+		stats = append(stats, g)
+	}
+	return stats, nil
+}
+
+// smiFloat and smiUint parse one nvidia-smi value; placeholders such as
+// "[N/A]" and "[Not Supported]" come back nil.
+func smiFloat(v string) *float64 {
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return nil
+	}
+	return &f
+}
+
+func smiUint(v string) *uint64 {
+	n, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+// humanGPUs renders the GPU column as "g0=97.0/45.2" (util%/mem%) per GPU.
+func (s Snapshot) humanGPUs() string {
+	if len(s.GPUs) == 0 {
+		return "-"
+	}
+	parts := make([]string, len(s.GPUs))
+	for i, g := range s.GPUs {
+		parts[i] = fmt.Sprintf("g%d=%s/%s", g.Index, optFloat(g.UtilPct, 1), optFloat(g.MemUsedPct, 1))
+	}
+	return strings.Join(parts, " ")
+}
+
+func init() {
+	collectCmd.Flags().BoolVar(&gpus, "gpu", false, "report NVIDIA GPU utilization, memory and temperature via nvidia-smi (omitted without one)")
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// smiOutput is nvidia-smi --query-gpu=... --format=csv,noheader,nounits on
+// a box with a data-center card and an old consumer card that reports
+// neither utilization nor memory.
+const smiOutput = `0, NVIDIA A100-SXM4-40GB, 87, 10240, 40960, 61
+1, NVIDIA GeForce GT 710, [N/A], [Not Supported], [Not Supported], 38
+`
+
+func TestParseGPUs(t *testing.T) {
+	gs, err := parseGPUs(smiOutput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gs) != 2 {
+		t.Fatalf("got %d GPUs, want 2", len(gs))
+	}
+	a100 := gs[0]
+	if a100.Name != "NVIDIA A100-SXM4-40GB" || *a100.UtilPct != 87 || *a100.MemUsedMB != 10240 || *a100.MemTotalMB != 40960 || *a100.TempC != 61 {
+		t.Errorf("A100 = %+v", a100)
+	}
+	if a100.MemUsedPct == nil || *a100.MemUsedPct != 25 {
+		t.Errorf("A100 memory used = %v%%, want 25%%", a100.MemUsedPct)
+	}
+	gt := gs[1]
+	if gt.Index != 1 || gt.UtilPct != nil || gt.MemUsedMB != nil || gt.MemTotalMB != nil || gt.MemUsedPct != nil {
+		t.Errorf("unsupported readings of the GT 710 weren't left out: %+v", gt)
+	}
+	if gt.TempC == nil || *gt.TempC != 38 {
+		t.Errorf("GT 710 temperature = %v, want 38", gt.TempC)
+	}
+}
+
+func TestParseGPUsZeroMemory(t *testing.T) {
+	// MIG-partitioned and vGPU instances can report 0 MiB total
+	gs, err := parseGPUs("0, GRID A100D-1-5C, 0, 0, 0, 30\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gs[0].MemUsedPct != nil {
+		t.Errorf("MemUsedPct = %v with 0 MiB total, want nil", *gs[0].MemUsedPct)
+	}
+}
+
+func TestParseGPUsBadOutput(t *testing.T) {
+	for _, out := range []string{
+		"0, Tesla T4, 0, 0, 15360\n",         // a column short: a newer query than the driver knows
+		"GPU 0, Tesla T4, 0, 0, 15360, 34\n", // index isn't a number
+		"0, \"Tesla T4, 0, 0, 15360, 34\n",   // broken quoting
+	} {
+		if gs, err := parseGPUs(out); err == nil {
+			t.Errorf("parseGPUs(%q) = %+v, want an error", out, gs)
+		}
+	}
+	if gs, err := parseGPUs(""); err != nil || gs != nil {
+		t.Errorf("parseGPUs(\"\") = %+v, %v; want nothing", gs, err)
+	}
+}
+
+// fakeSMI puts an nvidia-smi on PATH that prints out and exits with code.
+func fakeSMI(t *testing.T, out string, code int) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("needs a shell script on PATH")
+	}
+	dir := t.TempDir()
+	// PATH holds only the fake, so the script sticks to shell builtins
+	script := fmt.Sprintf("#!/bin/sh\nprintf '%%s' '%s'\nexit %d\n", out, code)
+	if err := os.WriteFile(filepath.Join(dir, "nvidia-smi"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+}
+
+func TestReadGPUs(t *testing.T) {
+	fakeSMI(t, smiOutput, 0)
+	gs, err := readGPUs(context.Background())
+	if err != nil || len(gs) != 2 {
+		t.Fatalf("readGPUs() = %+v, %v; want two GPUs", gs, err)
+	}
+}
+
+func TestReadGPUsNoCard(t *testing.T) {
+	// What nvidia-smi says with the driver installed but no card present
+	fakeSMI(t, "No devices were found\n", 6)
+	if gs, err := readGPUs(context.Background()); !errors.Is(err, errUnsupported) {
+		t.Errorf("readGPUs() = %+v, %v; want errUnsupported", gs, err)
+	}
+}
+
+func TestReadGPUsNoDriver(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	if gs, err := readGPUs(context.Background()); !errors.Is(err, errUnsupported) {
+		t.Errorf("readGPUs() = %+v, %v; want errUnsupported", gs, err)
+	}
+}
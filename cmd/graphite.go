@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var graphitePrefix string
+
+// graphiteNode makes s safe to use as a single Graphite path component.
+// Dots would otherwise split a hostname into several levels.
+func graphiteNode(s string) string {
+	s = strings.Trim(s, "/\\")
+	if s == "" {
+		return "root"
+	}
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '.', '/', '\\', ' ', ':':
+			return '_'
+		}
+		return r
+	}, s)
+}
+
+// writeGraphite renders s in the Carbon plaintext protocol, one
+// "path value timestamp" line per metric.
+func writeGraphite(w io.Writer, s Snapshot) error {
+	var b strings.Builder
+	base := graphiteNode(s.Host)
+	if graphitePrefix != "" {
+		base = graphitePrefix + "." + base
+	}
+	ts := s.Timestamp.Unix()
+	line := func(path string, v float64) {
+		fmt.Fprintf(&b, "%s.%s %s %d\n", base, path, strconv.FormatFloat(v, 'f', -1, 64), ts)
+	}
+	opt := func(path string, v *float64) {
+		if v != nil {
+			line(path, *v)
+		}
+	}
+	optUint := func(path string, v *uint64) {
+		if v != nil {
+			line(path, float64(*v))
+		}
+	}
+
+	line("uptime.seconds", float64(s.UptimeSec))
+	opt("cpu.percent", s.CPUPercent)
+	for i, v := range s.CPUPerCore {
+		line(fmt.Sprintf("cpu.core%d.percent", i), v)
+	}
+	opt("load.load1", s.Load1)
+	opt("load.load5", s.Load5)
+	opt("load.load15", s.Load15)
+	opt("load.per_core", s.LoadPerCore)
+	optUint("mem.used_mb", s.MemUsedMB)
+	optUint("mem.total_mb", s.MemTotalMB)
+	opt("mem.used_pct", s.MemUsedPct)
+	optUint("swap.used_mb", s.SwapUsedMB)
+	optUint("swap.total_mb", s.SwapTotalMB)
+	opt("swap.used_pct", s.SwapUsedPct)
+	for _, d := range s.Disks {
+		p := "disk." + graphiteNode(d.Path)
+		line(p+".used_gb", d.UsedGB)
+		line(p+".total_gb", d.TotalGB)
+		line(p+".used_pct", d.UsedPct)
+		if d.InodesUsedPct != nil {
+			line(p+".inodes_used_pct", *d.InodesUsedPct)
+		}
+	}
+	for _, d := range s.DiskIO {
+		p := "diskio." + graphiteNode(d.Name)
+		line(p+".read_bytes", float64(d.ReadBytes))
+		line(p+".write_bytes", float64(d.WriteBytes))
+		line(p+".read_count", float64(d.ReadCount))
+		line(p+".write_count", float64(d.WriteCount))
+	}
+	for _, t := range s.Temperatures {
+		line("temp."+graphiteNode(t.Key), t.Current)
+	}
+	optUint("net.bytes_in", s.NetBytesIn)
+	optUint("net.bytes_out", s.NetBytesOut)
+	opt("net.rate_in_bps", s.NetRateInBps)
+	opt("net.rate_out_bps", s.NetRateOutBps)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func init() {
+	collectCmd.Flags().StringVar(&graphitePrefix, "graphite-prefix", "gostats", "leading namespace for --format graphite metric paths")
+}
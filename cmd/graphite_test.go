@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGraphiteNode(t *testing.T) {
+	// Every separator Carbon or a shell would trip over becomes _; a path
+	// that is nothing but slashes is the root filesystem
+	for in, want := range map[string]string{
+		"web-1.prod.example.com": "web-1_prod_example_com",
+		"/":                      "root",
+		"/var/lib/docker":        "var_lib_docker",
+		`C:\`:                    "C_",
+		"/media/My Drive":        "media_My_Drive",
+		"coretemp_package_id_0":  "coretemp_package_id_0",
+		"nvme0n1":                "nvme0n1",
+	} {
+		if got := graphiteNode(in); got != want {
+			t.Errorf("graphiteNode(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestWriteGraphite(t *testing.T) {
+	old := graphitePrefix
+	t.Cleanup(func() { graphitePrefix = old })
+	graphitePrefix = "servers.eu"
+
+	s := Snapshot{
+		Timestamp:  time.Unix(1772366400, 0),
+		Host:       "db-2.prod.example.com",
+		UptimeSec:  86400,
+		CPUPercent: ptr(3.25),
+		Disks:      []DiskStat{{Path: "/", UsedGB: 20, TotalGB: 80, UsedPct: 25}},
+		DiskIO:     []DiskIOStat{{Name: "nvme0n1", ReadBytes: 1 << 40}},
+	}
+	var b strings.Builder
+	if err := writeGraphite(&b, s); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	// The prefix keeps its dots, the host is one node, and large counters
+	// aren't written in exponent form
+	for _, want := range []string{
+		"servers.eu.db-2_prod_example_com.uptime.seconds 86400 1772366400",
+		"servers.eu.db-2_prod_example_com.cpu.percent 3.25 1772366400",
+		"servers.eu.db-2_prod_example_com.disk.root.used_pct 25 1772366400",
+		"servers.eu.db-2_prod_example_com.diskio.nvme0n1.read_bytes 1099511627776 1772366400",
+	} {
+		if !slices.Contains(lines, want) {
+			t.Errorf("no line %q in\n%s", want, b.String())
+		}
+	}
+	for _, l := range lines {
+		if f := strings.Fields(l); len(f) != 3 {
+			t.Errorf("line %q is not \"path value timestamp\"", l)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Metric groups that can be toggled with --only and the --no-* flags. Host
+// identity (hostname, OS, uptime) is always collected.
+const (
+	groupCPU  = "cpu"
+	groupLoad = "load"
+	groupMem  = "mem"
+	groupDisk = "disk"
+	groupNet  = "net"
+)
+
+var allGroups = []string{groupCPU, groupLoad, groupMem, groupDisk, groupNet}
+
+var (
+	onlyGroups                          []string
+	noCPU, noLoad, noMem, noDisk, noNet bool
+
+	// skipGroups is filled by resolveGroups. The zero value collects
+	// everything, which is what callers that don't resolve flags (serve) want.
+	skipGroups map[string]bool
+)
+
+func groupEnabled(g string) bool {
+	return !skipGroups[g]
+}
+
+// resolveGroups builds skipGroups from --only and the --no-* flags. --only
+// narrows the set first; --no-* then removes from whatever remains.
+func resolveGroups() error {
+	skipGroups = map[string]bool{}
+	if len(onlyGroups) > 0 {
+		for _, g := range onlyGroups {
+			if !slices.Contains(allGroups, g) {
+				return fmt.Errorf("unknown metric group %q in --only (want %s)", g, strings.Join(allGroups, ","))
+			}
+		}
+		for _, g := range allGroups {
+			if !slices.Contains(onlyGroups, g) {
+				skipGroups[g] = true
+			}
+		}
+	}
+	for g, off := range map[string]bool{groupCPU: noCPU, groupLoad: noLoad, groupMem: noMem, groupDisk: noDisk, groupNet: noNet} {
+		if off {
+			skipGroups[g] = true
+		}
+	}
+	return nil
+}
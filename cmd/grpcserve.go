@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/qovert/gostats/gostatspb"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	grpcAddr     string
+	grpcInterval time.Duration
+)
+
+// grpcServer streams the hub's snapshots to every Stream caller.
+type grpcServer struct {
+	gostatspb.UnimplementedGostatsServer
+	hub *hub
+}
+
+func (g *grpcServer) Stream(_ *gostatspb.StreamRequest, stream grpc.ServerStreamingServer[gostatspb.Snapshot]) error {
+	snaps, unsubscribe := g.hub.subscribe()
+	defer unsubscribe()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil // client went away
+		case s, ok := <-snaps:
+			if !ok {
+				return nil // shutting down
+			}
+			if err := stream.Send(snapshotProto(s)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// snapshotProto converts a snapshot to its wire form. Optional metrics map
+// onto proto3 optional fields, so an uncollected metric stays unset.
+func snapshotProto(s Snapshot) *gostatspb.Snapshot {
+	p := &gostatspb.Snapshot{
+		Ts:                timestamppb.New(s.Timestamp),
+		Host:              s.Host,
+		Os:                s.OS,
+		UptimeSec:         s.UptimeSec,
+		KernelVersion:     s.KernelVersion,
+		BootTime:          s.BootTime,
+		Labels:            s.Labels,
+		CpuPercent:        s.CPUPercent,
+		CpuPerCore:        s.CPUPerCore,
+		Load1:             s.Load1,
+		Load5:             s.Load5,
+		Load15:            s.Load15,
+		LoadPerCore:       s.LoadPerCore,
+		MemUsedMb:         s.MemUsedMB,
+		MemTotalMb:        s.MemTotalMB,
+		MemUsedPct:        s.MemUsedPct,
+		MemAvailableMb:    s.MemAvailableMB,
+		MemCachedMb:       s.MemCachedMB,
+		MemBuffersMb:      s.MemBuffersMB,
+		SwapUsedMb:        s.SwapUsedMB,
+		SwapTotalMb:       s.SwapTotalMB,
+		SwapUsedPct:       s.SwapUsedPct,
+		NetBytesIn:        s.NetBytesIn,
+		NetBytesOut:       s.NetBytesOut,
+		NetRateInBps:      s.NetRateInBps,
+		NetRateOutBps:     s.NetRateOutBps,
+		NetErrIn:          s.NetErrIn,
+		NetErrOut:         s.NetErrOut,
+		NetDropIn:         s.NetDropIn,
+		NetDropOut:        s.NetDropOut,
+		NetErrInPerSec:    s.NetErrInRate,
+		NetErrOutPerSec:   s.NetErrOutRate,
+		NetDropInPerSec:   s.NetDropInRate,
+		NetDropOutPerSec:  s.NetDropOutRate,
+		CtxSwitches:       s.CtxSwitches,
+		Interrupts:        s.Interrupts,
+		CtxSwitchesPerSec: s.CtxSwitchRate,
+		InterruptsPerSec:  s.InterruptRate,
+		FdsOpen:           s.FDsOpen,
+		FdsMax:            s.FDsMax,
+		FdsUsedPct:        s.FDsUsedPct,
+		UserCount:         s.UserCount,
+		ProcCount:         s.ProcCount,
+		ThreadCount:       s.ThreadCount,
+		ZombieCount:       s.ZombieCount,
+		BatteryPercent:    s.BatteryPercent,
+		BatteryCharging:   s.BatteryCharging,
+		Errors:            s.Errors,
+	}
+	if c := s.Cloud; c != nil {
+		p.Cloud = &gostatspb.CloudMeta{Provider: c.Provider, InstanceId: c.InstanceID, Region: c.Region, Zone: c.Zone, InstanceType: c.InstanceType}
+	}
+	if t := s.CPUTimes; t != nil {
+		p.CpuTimes = &gostatspb.CPUTimes{User: t.User, System: t.System, Idle: t.Idle, Iowait: t.Iowait, Irq: t.Irq, Softirq: t.Softirq, Steal: t.Steal}
+	}
+	for _, d := range s.Disks {
+		p.Disks = append(p.Disks, &gostatspb.DiskStat{
+			Path: d.Path, UsedGb: d.UsedGB, TotalGb: d.TotalGB, UsedPct: d.UsedPct,
+			InodesUsed: d.InodesUsed, InodesTotal: d.InodesTotal, InodesUsedPct: d.InodesUsedPct,
+		})
+	}
+	for _, d := range s.DiskIO {
+		p.DiskIo = append(p.DiskIo, &gostatspb.DiskIOStat{
+			Name: d.Name, ReadBytes: d.ReadBytes, WriteBytes: d.WriteBytes, ReadCount: d.ReadCount, WriteCount: d.WriteCount,
+			ReadBps: d.ReadBps, WriteBps: d.WriteBps, ReadOpsPerSec: d.ReadOpsS, WriteOpsPerSec: d.WriteOpsS,
+		})
+	}
+	for _, n := range s.NetInterfaces {
+		p.NetInterfaces = append(p.NetInterfaces, &gostatspb.NetStat{
+			Name: n.Name, BytesIn: n.BytesIn, BytesOut: n.BytesOut, PacketsIn: n.PacketsIn, PacketsOut: n.PacketsOut,
+			ErrIn: n.ErrIn, ErrOut: n.ErrOut, DropIn: n.DropIn, DropOut: n.DropOut,
+		})
+	}
+	if len(s.TCPStates) > 0 {
+		p.TcpStates = make(map[string]int64, len(s.TCPStates))
+		for st, n := range s.TCPStates {
+			p.TcpStates[st] = int64(n)
+		}
+	}
+	if ps := s.Ports; ps != nil {
+		p.Ports = &gostatspb.PortSummary{EstablishedOutbound: int64(ps.EstablishedOutbound)}
+		for _, l := range ps.Listening {
+			p.Ports.Listening = append(p.Ports.Listening, &gostatspb.ListenSocket{Addr: l.Addr, Port: l.Port})
+		}
+		if len(ps.EstablishedByPort) > 0 {
+			p.Ports.EstablishedByPort = make(map[uint32]int64, len(ps.EstablishedByPort))
+			for port, n := range ps.EstablishedByPort {
+				p.Ports.EstablishedByPort[port] = int64(n)
+			}
+		}
+	}
+	if cg := s.Cgroup; cg != nil {
+		p.Cgroup = &gostatspb.CgroupStat{
+			Version: int32(cg.Version), MemLimitMb: cg.MemLimitMB, MemUsedMb: cg.MemUsedMB, MemUsedPct: cg.MemUsedPct,
+			CpuLimitCores: cg.CPULimitCores, CpuPercent: cg.CPUPercent,
+		}
+	}
+	if sm := s.Smoothed; sm != nil {
+		p.Smoothed = &gostatspb.Smoothed{
+			Window: int32(sm.Window), CpuPercent: sm.CPUPercent, MemUsedPct: sm.MemUsedPct,
+			NetRateInBps: sm.NetRateInBps, NetRateOutBps: sm.NetRateOutBps,
+		}
+	}
+	for _, u := range s.Users {
+		p.Users = append(p.Users, &gostatspb.UserSession{User: u.User, Terminal: u.Terminal, Host: u.Host})
+	}
+	for _, t := range s.Temperatures {
+		p.Temperatures = append(p.Temperatures, &gostatspb.SensorTemp{Key: t.Key, Current: t.Current, High: t.High, Critical: t.Critical})
+	}
+	for _, ps := range s.Processes {
+		p.Processes = append(p.Processes, &gostatspb.ProcStat{Pid: ps.PID, Name: ps.Name, CpuPercent: ps.CPUPercent, MemMb: ps.MemMB})
+	}
+	return p
+}
+
+var grpcServeCmd = &cobra.Command{
+	Use:   "grpc-serve",
+	Short: "Stream snapshots to gRPC clients",
+	Long: `grpc-serve exposes the gostats.v1.Gostats service (see gostatspb/gostats.proto).
+Its Stream RPC sends a snapshot every --interval to each connected client. One
+collection loop runs for all clients, whether or not any are connected.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if grpcInterval <= 0 {
+			return fmt.Errorf("--interval must be positive")
+		}
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+
+		lis, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			return err
+		}
+		h := newHub()
+		srv := grpc.NewServer()
+		gostatspb.RegisterGostatsServer(srv, &grpcServer{hub: h})
+
+		errc := make(chan error, 1)
+		go func() { errc <- srv.Serve(lis) }()
+		go h.run(ctx, grpcInterval)
+		slog.Info("serving gRPC", "addr", lis.Addr().String())
+
+		select {
+		case err := <-errc:
+			return err
+		case <-ctx.Done():
+		}
+
+		// The hub closes every subscription once ctx is done, which ends the
+		// open streams and lets GracefulStop return; Stop is the fallback.
+		stopped := make(chan struct{})
+		go func() {
+			srv.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(5 * time.Second):
+			srv.Stop()
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(grpcServeCmd)
+	grpcServeCmd.Flags().StringVar(&grpcAddr, "addr", ":9090", "address to listen on")
+	grpcServeCmd.Flags().DurationVar(&grpcInterval, "interval", 5*time.Second, "how often to collect and send a snapshot")
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+var highRes bool
+
+// hiresTicker ticks on exact multiples of every from start, timed on the
+// monotonic clock so wall-clock steps don't disturb it. A tick that comes
+// due while the previous sample is still running is skipped rather than
+// queued: the next tick is the first deadline still ahead, so an overrun
+// costs a slot instead of piling up drift.
+type hiresTicker struct {
+	C     <-chan time.Time
+	start time.Time
+	every time.Duration
+	done  chan struct{}
+}
+
+func newHiresTicker(start time.Time, every time.Duration) *hiresTicker {
+	c := make(chan time.Time)
+	h := &hiresTicker{C: c, start: start, every: every, done: make(chan struct{})}
+	go func() {
+		t := time.NewTimer(every)
+		defer t.Stop()
+		var last time.Time
+		for n := time.Duration(1); ; {
+			next := start.Add(n * every)
+			t.Reset(time.Until(next))
+			select {
+			case <-h.done:
+				return
+			case <-t.C:
+			}
+			select {
+			case <-h.done:
+				return
+			case c <- next:
+			}
+			now := time.Now()
+			due := time.Since(start)/every + 1
+			if !last.IsZero() {
+				slog.Debug("high-res tick", "target", every, "actual", now.Sub(last).Round(time.Microsecond), "skipped", int64(due-n-1))
+			}
+			last, n = now, due
+		}
+	}()
+	return h
+}
+
+func (h *hiresTicker) stop() {
+	close(h.done)
+}
+
+// startHighRes sets up --high-res streaming. CPU percent is measured over
+// the whole interval since the previous sample instead of a blocking
+// --cpu-window, so one probe collection seeds it, seeds the rates through
+// prev and times how long a collection takes. An interval shorter than that
+// can't be kept; it is widened to the smallest multiple that can, with a
+// warning. The first sample is emitted one interval after the probe.
+func startHighRes(ctx context.Context, prev **Snapshot) *hiresTicker {
+	cpuSinceLast = true
+	start := time.Now()
+	seed, err := collectOnce(ctx)
+	took := time.Since(start)
+	if err == nil {
+		*prev = &seed
+	}
+	every := interval
+	if took >= every {
+		every = (took/interval + 1) * interval
+		slog.Warn("--interval is shorter than one collection takes; sampling less often", "interval", interval, "collection", took.Round(time.Millisecond), "using", every)
+	}
+	return newHiresTicker(start, every)
+}
+
+func init() {
+	collectCmd.Flags().BoolVar(&highRes, "high-res", false, "keep short --interval values (e.g. 100ms) exact: tick on fixed monotonic deadlines and measure CPU over the whole interval instead of --cpu-window")
+}
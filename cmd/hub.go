@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// hub runs a single collection loop and fans every snapshot out to its
+// subscribers, so concurrent clients share one collection instead of each
+// walking /proc on their own.
+type hub struct {
+	mu     sync.Mutex
+	subs   map[chan Snapshot]struct{}
+	latest *Snapshot
+	done   bool
+
+	// Set by runOnDemand: the loop only runs while someone is subscribed.
+	parent context.Context
+	every  time.Duration
+	stop   context.CancelFunc
+}
+
+func newHub() *hub {
+	return &hub{subs: map[chan Snapshot]struct{}{}}
+}
+
+// subscribe returns a channel of snapshots, starting with the most recent one
+// if there is any, and a func to unsubscribe. A subscriber that falls behind
+// skips to the newest snapshot rather than holding up the loop. The channel
+// is closed when the hub stops.
+func (h *hub) subscribe() (<-chan Snapshot, func()) {
+	c := make(chan Snapshot, 1)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.done {
+		close(c)
+		return c, func() {}
+	}
+	if h.latest != nil {
+		c <- *h.latest
+	}
+	h.subs[c] = struct{}{}
+	if h.parent != nil && h.stop == nil {
+		ctx, stop := context.WithCancel(h.parent)
+		h.stop = stop
+		go h.loop(ctx, h.every)
+	}
+	return c, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.subs[c]; ok {
+			delete(h.subs, c)
+			close(c)
+		}
+		if len(h.subs) == 0 && h.stop != nil {
+			h.stop()
+			h.stop = nil
+			h.latest = nil
+		}
+	}
+}
+
+func (h *hub) publish(s Snapshot) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.latest = &s
+	for c := range h.subs {
+		select {
+		case <-c: // drop the stale one
+		default:
+		}
+		c <- s
+	}
+}
+
+// run collects every interval until ctx is done, then closes all
+// subscriptions.
+func (h *hub) run(ctx context.Context, every time.Duration) {
+	defer h.close()
+	h.loop(ctx, every)
+}
+
+// runOnDemand is run for servers that shouldn't sample an idle machine: the
+// first subscriber starts the loop and the last one to leave stops it. It
+// blocks until ctx is done.
+func (h *hub) runOnDemand(ctx context.Context, every time.Duration) {
+	h.mu.Lock()
+	h.parent, h.every = ctx, every
+	h.mu.Unlock()
+	<-ctx.Done()
+	h.close()
+}
+
+func (h *hub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.done = true
+	h.parent = nil
+	for c := range h.subs {
+		delete(h.subs, c)
+		close(c)
+	}
+}
+
+// loop collects every interval until ctx is done. A failed collection is
+// logged and retried on the next tick so one bad cycle doesn't end every
+// client's stream.
+func (h *hub) loop(ctx context.Context, every time.Duration) {
+	t := time.NewTicker(every)
+	defer t.Stop()
+	var prev *Snapshot
+	for {
+		snap, err := collectOnce(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			slog.Warn("collection failed", "err", err)
+		} else {
+			snap.applyRates(prev)
+			prev = &snap
+			h.publish(snap)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	stopWhenIdle string
+	idleFor      time.Duration
+
+	// idleConds are the parsed --stop-when-idle conditions
+	idleConds []idleCond
+)
+
+// idleAliases are the short names --stop-when-idle accepts besides the
+// metric names used by --summary. net is the combined in and out rate.
+var idleAliases = map[string][]string{
+	"cpu":  {"cpu_percent"},
+	"mem":  {"mem_used_pct"},
+	"load": {"load1"},
+	"net":  {"net_rate_in_bps", "net_rate_out_bps"},
+}
+
+// idleCond is one metric<limit condition. A condition over several metrics
+// compares their sum.
+type idleCond struct {
+	spec    string
+	metrics []string
+	limit   float64
+}
+
+// resolveIdle parses --stop-when-idle, e.g. "cpu<5,net<1000".
+func resolveIdle() error {
+	idleConds = nil
+	if stopWhenIdle == "" {
+		return nil
+	}
+	if interval <= 0 {
+		return fmt.Errorf("--stop-when-idle needs --interval")
+	}
+	if idleFor <= 0 {
+		return fmt.Errorf("--idle-for must be positive, got %s", idleFor)
+	}
+	for _, spec := range strings.Split(stopWhenIdle, ",") {
+		spec = strings.TrimSpace(spec)
+		name, limit, ok := strings.Cut(spec, "<")
+		v, err := strconv.ParseFloat(strings.TrimSpace(limit), 64)
+		if !ok || err != nil || name == "" {
+			return fmt.Errorf("invalid --stop-when-idle condition %q (want e.g. cpu<5)", spec)
+		}
+		name = strings.TrimSpace(name)
+		metrics, ok := idleAliases[name]
+		if !ok {
+			if !knownMetric(name) {
+				return fmt.Errorf("unknown metric %q in --stop-when-idle (want %s)", name, strings.Join(idleMetricNames(), ", "))
+			}
+			metrics = []string{name}
+		}
+		idleConds = append(idleConds, idleCond{spec: spec, metrics: metrics, limit: v})
+	}
+	return nil
+}
+
+// instanceMetrics are the metricValues names whose instances are only
+// found at run time, matched by prefix.
+var instanceMetrics = []string{"gpu_util_pct[", "gpu_mem_used_pct[", "gpu_temp_c[", "custom."}
+
+// fixedMetricNames are the names metricValues gives a snapshot with every
+// metric present, per-instance ones for the --disk-path targets.
+func fixedMetricNames() []string {
+	one := ptr(1.0)
+	line := PSILine{}
+	res := func() *PSIResource { return &PSIResource{Full: &line} }
+	s := Snapshot{
+		CPUPercent: one, CPUMaxPercent: one, CPUInfo: &CPUInfo{CurMHz: one},
+		Load1: one, LoadPerCore: one, MemUsedPct: one, SwapUsedPct: one,
+		CtxSwitchRate: one, InterruptRate: one, FDsUsedPct: one,
+		PSI:          &PSI{CPU: res(), Memory: res(), IO: res()},
+		Cgroup:       &CgroupStat{MemUsedPct: one, CPUPercent: one},
+		NetRateInBps: one, NetRateOutBps: one, NetErrInRate: one, NetErrOutRate: one,
+		NetDropInRate: one, NetDropOutRate: one,
+	}
+	for _, p := range diskTargets() {
+		s.Disks = append(s.Disks, DiskStat{Path: p, InodesUsedPct: one})
+	}
+	var names []string
+	for _, mv := range metricValues(s) {
+		names = append(names, mv.Name)
+	}
+	return names
+}
+
+// knownMetric reports whether name is one metricValues can produce.
+func knownMetric(name string) bool {
+	if slices.Contains(fixedMetricNames(), name) {
+		return true
+	}
+	for _, prefix := range instanceMetrics {
+		if rest, ok := strings.CutPrefix(name, prefix); ok && rest != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// idleMetricNames lists what --stop-when-idle accepts, for its error.
+func idleMetricNames() []string {
+	names := slices.Sorted(maps.Keys(idleAliases))
+	names = append(names, fixedMetricNames()...)
+	return append(names, "gpu_util_pct[N]", "gpu_mem_used_pct[N]", "gpu_temp_c[N]", "custom.KEY")
+}
+
+// idleWatch ends a stream once every --stop-when-idle condition has held
+// for --idle-for. Samples from a quiet stretch are held back from the
+// summary until the stretch is broken, so a run that stops on idle
+// summarizes only its active period. A nil *idleWatch passes every sample
+// straight to the summary and never stops the run.
+type idleWatch struct {
+	since   time.Time // start of the current quiet stretch, or zero
+	pending []Snapshot
+	stopped bool
+}
+
+func newIdleWatch() *idleWatch {
+	if len(idleConds) == 0 {
+		return nil
+	}
+	return &idleWatch{}
+}
+
+// observe records s and reports whether the system has now been idle for
+// long enough.
+func (w *idleWatch) observe(s Snapshot, sum *summary) bool {
+	if w == nil {
+		sum.add(s)
+		return false
+	}
+	if !isIdle(s) {
+		for _, p := range w.pending {
+			sum.add(p)
+		}
+		w.pending, w.since = nil, time.Time{}
+		sum.add(s)
+		return false
+	}
+	if w.since.IsZero() {
+		w.since = s.Timestamp
+	}
+	w.pending = append(w.pending, s)
+	if s.Timestamp.Sub(w.since) < idleFor {
+		return false
+	}
+	slog.Info("system idle; stopping", "conditions", stopWhenIdle, "for", idleFor)
+	w.stopped = true
+	return true
+}
+
+// finish adds a quiet stretch the run ended in for another reason (count,
+// duration, ctrl-c) to the summary after all.
+func (w *idleWatch) finish(sum *summary) {
+	if w == nil || w.stopped {
+		return
+	}
+	for _, p := range w.pending {
+		sum.add(p)
+	}
+	w.pending = nil
+}
+
+// isIdle reports whether s meets every condition. A metric that wasn't
+// collected, such as net rates on the first sample, doesn't count as idle.
+func isIdle(s Snapshot) bool {
+	vals := map[string]float64{}
+	for _, mv := range metricValues(s) {
+		vals[mv.Name] = mv.Value
+	}
+	for _, c := range idleConds {
+		var total float64
+		for _, m := range c.metrics {
+			v, ok := vals[m]
+			if !ok {
+				return false
+			}
+			total += v
+		}
+		if total >= c.limit {
+			return false
+		}
+	}
+	return true
+}
+
+func init() {
+	collectCmd.Flags().StringVar(&stopWhenIdle, "stop-when-idle", "", "end the stream once these metrics stay under their limits for --idle-for, e.g. cpu<5,net<1000 (cpu, mem, load, net or any --summary metric name)")
+	collectCmd.Flags().DurationVar(&idleFor, "idle-for", 30*time.Second, "how long the --stop-when-idle conditions must hold")
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setIdleFlags sets --stop-when-idle and friends for one test and parses
+// them, restoring the previous values afterwards.
+func setIdleFlags(t *testing.T, spec string, every, quiet time.Duration) error {
+	t.Helper()
+	oldSpec, oldInterval, oldFor := stopWhenIdle, interval, idleFor
+	t.Cleanup(func() { stopWhenIdle, interval, idleFor, idleConds = oldSpec, oldInterval, oldFor, nil })
+	stopWhenIdle, interval, idleFor = spec, every, quiet
+	return resolveIdle()
+}
+
+func TestResolveIdleAliases(t *testing.T) {
+	if err := setIdleFlags(t, "cpu<5, net < 1000,gpu_temp_c[1]<40", time.Second, 30*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	want := []idleCond{
+		{spec: "cpu<5", metrics: []string{"cpu_percent"}, limit: 5},
+		{spec: "net < 1000", metrics: []string{"net_rate_in_bps", "net_rate_out_bps"}, limit: 1000},
+		{spec: "gpu_temp_c[1]<40", metrics: []string{"gpu_temp_c[1]"}, limit: 40},
+	}
+	if !reflect.DeepEqual(idleConds, want) {
+		t.Errorf("idleConds = %+v, want %+v", idleConds, want)
+	}
+}
+
+func TestResolveIdleRejects(t *testing.T) {
+	for spec, wantErr := range map[string]string{
+		"cpu>5":          "invalid --stop-when-idle condition",
+		"cpu<":           "invalid --stop-when-idle condition",
+		"<5":             "invalid --stop-when-idle condition",
+		"cpu<5,":         "invalid --stop-when-idle condition",
+		"cpu_pct<5":      `unknown metric "cpu_pct"`,
+		"custom.<5":      `unknown metric "custom."`,
+		"gpu_util_pct<5": `unknown metric "gpu_util_pct"`,
+	} {
+		err := setIdleFlags(t, spec, time.Second, 30*time.Second)
+		if err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("--stop-when-idle %q: got %v, want %q", spec, err, wantErr)
+		}
+	}
+	// The error for a typo lists the names that would have worked
+	err := setIdleFlags(t, "load_1<1", time.Second, 30*time.Second)
+	if err == nil || !strings.Contains(err.Error(), "load1") || !strings.Contains(err.Error(), "custom.KEY") {
+		t.Errorf("unknown metric error doesn't list the valid names: %v", err)
+	}
+	if err := setIdleFlags(t, "cpu<5", 0, 30*time.Second); err == nil {
+		t.Error("--stop-when-idle without --interval succeeded")
+	}
+	if err := setIdleFlags(t, "cpu<5", time.Second, 0); err == nil {
+		t.Error("--idle-for 0 succeeded")
+	}
+}
+
+// TestIdleWatchStops feeds a stream sampled every 10s through idleWatch:
+// a busy stretch, a short lull, more work, then a quiet tail.
+func TestIdleWatchStops(t *testing.T) {
+	if err := setIdleFlags(t, "cpu<5,net<1000", 10*time.Second, 30*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	readings := []struct{ cpu, netIn, netOut float64 }{
+		{80, 5e6, 1e6}, {75, 4e6, 1e6},
+		{2, 100, 100}, {1, 50, 50}, // a 10s lull is too short to stop on
+		{60, 2e6, 3e5},
+		{3, 200, 300}, // 500 B/s in and out together is quiet...
+		{1, 600, 500}, // ...but 1100 B/s ends that stretch
+		{1, 10, 10}, {0.5, 0, 0}, {0.5, 0, 0}, {0.4, 0, 0},
+		{0.3, 0, 0}, // never reached
+	}
+	w, sum := newIdleWatch(), newSummary()
+	stoppedAt := -1
+	for i, r := range readings {
+		s := Snapshot{
+			Timestamp:     start.Add(time.Duration(i) * 10 * time.Second),
+			CPUPercent:    ptr(r.cpu),
+			NetRateInBps:  ptr(r.netIn),
+			NetRateOutBps: ptr(r.netOut),
+		}
+		if w.observe(s, sum) {
+			stoppedAt = i
+			break
+		}
+	}
+	w.finish(sum)
+	// Quiet since sample 7 (cpu 1, 20 B/s); 30s later is sample 10
+	if stoppedAt != 10 {
+		t.Fatalf("stopped at sample %d, want 10", stoppedAt)
+	}
+	// The summary covers the run up to the final quiet stretch, including
+	// the short lull, but not the four samples that only waited to stop
+	if n := len(sum.values["cpu_percent"]); n != 7 {
+		t.Errorf("summary has %d cpu samples, want 7", n)
+	}
+}
+
+func TestIdleWatchMissingMetric(t *testing.T) {
+	if err := setIdleFlags(t, "net<1000", time.Second, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	// The first sample of a stream has no rates yet; that isn't idle
+	w, sum := newIdleWatch(), newSummary()
+	start := time.Now()
+	for i := range 5 {
+		if w.observe(Snapshot{Timestamp: start.Add(time.Duration(i) * time.Second), CPUPercent: ptr(0.0)}, sum) {
+			t.Fatalf("stopped at sample %d with no net rates collected", i)
+		}
+	}
+	// A run that ends for another reason keeps its quiet tail
+	w.observe(Snapshot{Timestamp: start.Add(5 * time.Second), NetRateInBps: ptr(1.0), NetRateOutBps: ptr(1.0)}, sum)
+	w.finish(sum)
+	if n := len(sum.values["net_rate_in_bps"]); n != 1 {
+		t.Errorf("summary has %d net samples after finish, want 1", n)
+	}
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var measurement string
+
+var (
+	influxMeasurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `)
+	influxKeyEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+)
+
+// influxLine accumulates the tag set and field set of one line-protocol point.
+type influxLine struct {
+	tags   []string
+	fields []string
+}
+
+func (l *influxLine) tag(k, v string) {
+	if v != "" { // empty tag values are invalid in line protocol
+		l.tags = append(l.tags, influxKeyEscaper.Replace(k)+"="+influxKeyEscaper.Replace(v))
+	}
+}
+
+func (l *influxLine) float(k string, v float64) {
+	l.fields = append(l.fields, influxKeyEscaper.Replace(k)+"="+strconv.FormatFloat(v, 'f', -1, 64))
+}
+
+func (l *influxLine) optFloat(k string, v *float64) {
+	if v != nil {
+		l.float(k, *v)
+	}
+}
+
+func (l *influxLine) uint(k string, v uint64) {
+	l.fields = append(l.fields, influxKeyEscaper.Replace(k)+"="+strconv.FormatUint(v, 10)+"i")
+}
+
+func (l *influxLine) optUint(k string, v *uint64) {
+	if v != nil {
+		l.uint(k, *v)
+	}
+}
+
+// writeInflux renders s as InfluxDB line protocol. Host-wide values go on a
+// single point; per-core, per-disk and per-sensor values get their own
+// "<measurement>_<kind>" points tagged with the instance they describe.
+func writeInflux(w io.Writer, s Snapshot) error {
+	var b strings.Builder
+	ts := strconv.FormatInt(s.Timestamp.UnixNano(), 10)
+	point := func(name string, fill func(l *influxLine)) {
+		l := &influxLine{}
+		l.tag("host", s.Host)
+		l.tag("os", s.OS)
+		for _, k := range sortedLabels(s.Labels) {
+			l.tag(k, s.Labels[k])
+		}
+		fill(l)
+		if len(l.fields) == 0 {
+			return
+		}
+		b.WriteString(influxMeasurementEscaper.Replace(name))
+		for _, t := range l.tags {
+			b.WriteByte(',')
+			b.WriteString(t)
+		}
+		b.WriteByte(' ')
+		b.WriteString(strings.Join(l.fields, ","))
+		b.WriteByte(' ')
+		b.WriteString(ts)
+		b.WriteByte('\n')
+	}
+
+	point(measurement, func(l *influxLine) {
+		l.uint("uptime_sec", s.UptimeSec)
+		l.optFloat("cpu_percent", s.CPUPercent)
+		l.optFloat("cpu_max_percent", s.CPUMaxPercent)
+		if s.CPUInfo != nil {
+			l.optFloat("cpu_mhz", s.CPUInfo.CurMHz)
+		}
+		l.optFloat("load1", s.Load1)
+		l.optFloat("load5", s.Load5)
+		l.optFloat("load15", s.Load15)
+		l.optFloat("load_per_core", s.LoadPerCore)
+		l.optUint("mem_used_mb", s.MemUsedMB)
+		l.optUint("mem_total_mb", s.MemTotalMB)
+		l.optFloat("mem_used_pct", s.MemUsedPct)
+		l.optUint("swap_used_mb", s.SwapUsedMB)
+		l.optUint("swap_total_mb", s.SwapTotalMB)
+		l.optFloat("swap_used_pct", s.SwapUsedPct)
+		l.optUint("net_bytes_in", s.NetBytesIn)
+		l.optUint("net_bytes_out", s.NetBytesOut)
+		l.optFloat("net_rate_in_bps", s.NetRateInBps)
+		l.optFloat("net_rate_out_bps", s.NetRateOutBps)
+	})
+	for i, v := range s.CPUPerCore {
+		point(measurement+"_cpu", func(l *influxLine) {
+			l.tag("core", strconv.Itoa(i))
+			l.float("percent", v)
+		})
+	}
+	for _, d := range s.Disks {
+		point(measurement+"_disk", func(l *influxLine) {
+			l.tag("path", d.Path)
+			l.float("used_gb", d.UsedGB)
+			l.float("total_gb", d.TotalGB)
+			l.float("used_pct", d.UsedPct)
+			if d.InodesUsedPct != nil {
+				l.uint("inodes_used", d.InodesUsed)
+				l.uint("inodes_total", d.InodesTotal)
+				l.float("inodes_used_pct", *d.InodesUsedPct)
+			}
+		})
+	}
+	for _, d := range s.DiskIO {
+		point(measurement+"_diskio", func(l *influxLine) {
+			l.tag("device", d.Name)
+			l.uint("read_bytes", d.ReadBytes)
+			l.uint("write_bytes", d.WriteBytes)
+			l.uint("read_count", d.ReadCount)
+			l.uint("write_count", d.WriteCount)
+			l.optFloat("read_bps", d.ReadBps)
+			l.optFloat("write_bps", d.WriteBps)
+		})
+	}
+	for _, t := range s.Temperatures {
+		point(measurement+"_temp", func(l *influxLine) {
+			l.tag("sensor", t.Key)
+			l.float("current", t.Current)
+		})
+	}
+	for _, g := range s.GPUs {
+		point(measurement+"_gpu", func(l *influxLine) {
+			l.tag("gpu", strconv.Itoa(g.Index))
+			l.tag("name", g.Name)
+			l.optFloat("util_pct", g.UtilPct)
+			l.optUint("mem_used_mb", g.MemUsedMB)
+			l.optUint("mem_total_mb", g.MemTotalMB)
+			l.optFloat("temp_c", g.TempC)
+		})
+	}
+	if s.PSI != nil {
+		for _, res := range s.PSI.resources() {
+			if res.r == nil {
+				continue
+			}
+			for _, ln := range res.r.lines() {
+				point(measurement+"_psi", func(l *influxLine) {
+					l.tag("resource", res.name)
+					l.tag("kind", ln.kind)
+					l.float("avg10", ln.l.Avg10)
+					l.float("avg60", ln.l.Avg60)
+					l.float("avg300", ln.l.Avg300)
+					l.uint("total_us", ln.l.TotalUs)
+				})
+			}
+		}
+	}
+	point(measurement+"_custom", func(l *influxLine) {
+		for _, k := range slices.Sorted(maps.Keys(s.Custom)) {
+			l.float(k, s.Custom[k])
+		}
+	})
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func init() {
+	collectCmd.Flags().StringVar(&measurement, "measurement", "gostats", "measurement name for --format influx")
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteInflux(t *testing.T) {
+	s := Snapshot{
+		Timestamp:  time.Unix(1772366400, 5e8),
+		Host:       "build box,1",
+		OS:         "linux/ubuntu",
+		UptimeSec:  3600,
+		Labels:     map[string]string{"role": "ci=true", "dc": "eu west"},
+		CPUPercent: ptr(12.5),
+		CPUPerCore: []float64{10, 15},
+		MemUsedMB:  ptr(uint64(2048)),
+		Disks: []DiskStat{
+			{Path: "/media/My Drive", UsedGB: 100, TotalGB: 400, UsedPct: 25},
+		},
+		GPUs: []GPUStat{{Index: 0, Name: "NVIDIA GeForce RTX 4090", TempC: ptr(48.0)}},
+	}
+	var b strings.Builder
+	if err := writeInflux(&b, s); err != nil {
+		t.Fatal(err)
+	}
+	// Tag keys and values escape commas, equals signs and spaces; the
+	// measurement only commas and spaces. Labels follow host and os in
+	// key order, and integers carry the i suffix.
+	tags := `host=build\ box\,1,os=linux/ubuntu,dc=eu\ west,role=ci\=true`
+	want := strings.Join([]string{
+		`gostats,` + tags + ` uptime_sec=3600i,cpu_percent=12.5,mem_used_mb=2048i 1772366400500000000`,
+		`gostats_cpu,` + tags + `,core=0 percent=10 1772366400500000000`,
+		`gostats_cpu,` + tags + `,core=1 percent=15 1772366400500000000`,
+		`gostats_disk,` + tags + `,path=/media/My\ Drive used_gb=100,total_gb=400,used_pct=25 1772366400500000000`,
+		`gostats_gpu,` + tags + `,gpu=0,name=NVIDIA\ GeForce\ RTX\ 4090 temp_c=48 1772366400500000000`,
+	}, "\n") + "\n"
+	if got := b.String(); got != want {
+		t.Errorf("writeInflux() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteInfluxMeasurement(t *testing.T) {
+	old := measurement
+	t.Cleanup(func() { measurement = old })
+	measurement = "host stats,v2"
+
+	var b strings.Builder
+	if err := writeInflux(&b, Snapshot{Timestamp: time.Unix(0, 0), Custom: map[string]float64{"queue depth": 3}}); err != nil {
+		t.Fatal(err)
+	}
+	// An empty host tag is dropped rather than written as host=
+	want := `host\ stats\,v2 uptime_sec=0i 0` + "\n" + `host\ stats\,v2_custom queue\ depth=3 0` + "\n"
+	if got := b.String(); got != want {
+		t.Errorf("writeInflux() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/cpu"
+	"github.com/shirou/gopsutil/v4/host"
+	"github.com/shirou/gopsutil/v4/mem"
+	"github.com/spf13/cobra"
+)
+
+var infoFormat string
+
+// HostInfo is the static description of a host: what it is rather than
+// how busy it is. Fields gopsutil can't determine are left empty.
+type HostInfo struct {
+	Hostname           string      `json:"hostname"`
+	OS                 string      `json:"os"`
+	Platform           string      `json:"platform"`
+	PlatformFamily     string      `json:"platform_family,omitempty"`
+	PlatformVersion    string      `json:"platform_version,omitempty"`
+	KernelVersion      string      `json:"kernel_version,omitempty"`
+	Arch               string      `json:"arch,omitempty"`
+	CPUModel           string      `json:"cpu_model,omitempty"`
+	CPUSockets         []CPUSocket `json:"cpu_sockets,omitempty"` // only when their models differ
+	CPUMaxMHz          *float64    `json:"cpu_max_mhz,omitempty"`
+	CPUCurMHz          *float64    `json:"cpu_cur_mhz,omitempty"`
+	CPUPhysicalCores   int         `json:"cpu_physical_cores,omitempty"`
+	CPULogicalCores    int         `json:"cpu_logical_cores,omitempty"`
+	MemTotalMB         uint64      `json:"mem_total_mb,omitempty"`
+	BootTime           string      `json:"boot_time,omitempty"` // RFC3339
+	Virtualization     string      `json:"virtualization,omitempty"`
+	VirtualizationRole string      `json:"virtualization_role,omitempty"`
+}
+
+// collectHostInfo gathers HostInfo, keeping whatever parts succeed; it only
+// fails if host.Info itself returns nothing.
+func collectHostInfo(ctx context.Context) (HostInfo, error) {
+	var hi HostInfo
+	h, err := host.InfoWithContext(ctx)
+	if h == nil {
+		return hi, err
+	}
+	hi = HostInfo{
+		Hostname:           h.Hostname,
+		OS:                 h.OS,
+		Platform:           h.Platform,
+		PlatformFamily:     h.PlatformFamily,
+		PlatformVersion:    h.PlatformVersion,
+		KernelVersion:      h.KernelVersion,
+		Arch:               h.KernelArch,
+		Virtualization:     h.VirtualizationSystem,
+		VirtualizationRole: h.VirtualizationRole,
+	}
+	if h.BootTime > 0 {
+		hi.BootTime = time.Unix(int64(h.BootTime), 0).Format(time.RFC3339)
+	}
+	var errs []error
+	if ci, err := readCPUInfo(ctx); err == nil {
+		hi.CPUModel, hi.CPUSockets, hi.CPUMaxMHz, hi.CPUCurMHz = ci.Model, ci.Sockets, ci.MaxMHz, ci.CurMHz
+	} else {
+		errs = append(errs, fmt.Errorf("cpu: %w", err))
+	}
+	if n, err := cpu.CountsWithContext(ctx, false); err == nil {
+		hi.CPUPhysicalCores = n
+	}
+	if n, err := cpu.CountsWithContext(ctx, true); err == nil {
+		hi.CPULogicalCores = n
+	}
+	if vm, err := mem.VirtualMemoryWithContext(ctx); err == nil {
+		hi.MemTotalMB = vm.Total / (1024 * 1024)
+	} else {
+		errs = append(errs, fmt.Errorf("mem: %w", err))
+	}
+	return hi, errors.Join(errs...)
+}
+
+func writeHostInfo(w io.Writer, hi HostInfo) error {
+	if infoFormat == formatJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(hi)
+	}
+	orDash := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+	count := func(n int) string {
+		if n == 0 {
+			return "-"
+		}
+		return strconv.Itoa(n)
+	}
+	mem := "-"
+	if hi.MemTotalMB > 0 {
+		mem = strconv.FormatUint(hi.MemTotalMB, 10) + " MB"
+	}
+	cpuModel := orDash(hi.CPUModel)
+	for i, so := range hi.CPUSockets {
+		if i == 0 {
+			cpuModel = ""
+		} else {
+			cpuModel += "; "
+		}
+		cpuModel += "socket " + so.ID + ": " + so.Model
+	}
+	clock := optFloat(hi.CPUCurMHz, 0) + " MHz now, " + optFloat(hi.CPUMaxMHz, 0) + " MHz max"
+	virt := orDash(hi.Virtualization)
+	if hi.Virtualization != "" && hi.VirtualizationRole != "" {
+		virt += " (" + hi.VirtualizationRole + ")"
+	}
+	_, err := io.WriteString(w, alignTable(
+		"Hostname:\t"+orDash(hi.Hostname),
+		"OS:\t"+orDash(hi.OS),
+		"Platform:\t"+orDash(hi.Platform)+" "+hi.PlatformVersion,
+		"Family:\t"+orDash(hi.PlatformFamily),
+		"Kernel:\t"+orDash(hi.KernelVersion),
+		"Arch:\t"+orDash(hi.Arch),
+		"CPU:\t"+cpuModel,
+		"Clock:\t"+clock,
+		"Cores:\t"+count(hi.CPUPhysicalCores)+" physical, "+count(hi.CPULogicalCores)+" logical",
+		"Memory:\t"+mem,
+		"Boot time:\t"+orDash(hi.BootTime),
+		"Virtualization:\t"+virt,
+	))
+	return err
+}
+
+var infoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Print static host facts once (OS, kernel, CPU, memory, virtualization)",
+	Long: `info prints what the host is rather than how busy it is: host name, OS and
+platform, kernel, architecture, CPU model, core counts and clock, total
+memory, boot time and virtualization system. It takes no samples, which
+makes it a cheap inventory probe.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if infoFormat != formatHuman && infoFormat != formatJSON {
+			return fmt.Errorf("unknown --format %q (want human or json)", infoFormat)
+		}
+		hi, err := collectHostInfo(context.Background())
+		if hi.Hostname == "" && err != nil {
+			return err
+		}
+		if err != nil {
+			slog.Warn("some host facts are unavailable", "err", err)
+		}
+		return writeHostInfo(os.Stdout, hi)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(infoCmd)
+	infoCmd.Flags().StringVar(&infoFormat, "format", formatHuman, "output format: human or json")
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+var jitter time.Duration
+
+// validateJitter keeps --jitter small enough that jittered samples can't
+// swap order or bunch up: each stays within its own interval.
+func validateJitter() error {
+	if jitter < 0 {
+		return fmt.Errorf("--jitter must not be negative, got %s", jitter)
+	}
+	if jitter > 0 && highRes {
+		return fmt.Errorf("--jitter conflicts with --high-res")
+	}
+	if jitter > 0 && interval > 0 && 2*jitter >= interval {
+		return fmt.Errorf("--jitter %s must be less than half of --interval %s", jitter, interval)
+	}
+	return nil
+}
+
+// waitJitter holds a tick back by a fresh random delay in [0, 2*jitter].
+// That averages --jitter, and --align starts the ticker --jitter early to
+// match, so aligned samples land uniformly within ±jitter of the boundary.
+// It reports false if the run ended while waiting.
+func waitJitter(ctx context.Context, deadline <-chan time.Time) bool {
+	if jitter <= 0 {
+		return true
+	}
+	t := time.NewTimer(rand.N(2*jitter + 1))
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-deadline:
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
+func init() {
+	collectCmd.Flags().DurationVar(&jitter, "jitter", 0, "offset each streaming sample by a random amount within ±this (e.g. 500ms), drawn afresh every tick, so a fleet's pushes spread out")
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+var (
+	kafkaBrokers  []string
+	kafkaTopic    string
+	kafkaBatch    int
+	kafkaTLS      bool
+	kafkaCA       string
+	kafkaSASL     string
+	kafkaUser     string
+	kafkaPassword string
+)
+
+// kafkaMaxAttempts is how often the writer retries a batch before dropping
+// it; with its default backoff that rides out a broker restart.
+// kafkaMaxPending caps the snapshots held back while no broker is reachable,
+// and kafkaTimeout bounds each hand-off so one doesn't hold up sampling.
+const (
+	kafkaMaxAttempts = 10
+	kafkaMaxPending  = 1000
+	kafkaTimeout     = 5 * time.Second
+)
+
+// kafkaSink produces each snapshot's JSON to --kafka-topic, keyed by host so
+// one host's samples stay ordered on one partition. Writes are asynchronous
+// and batched; the writer retries failed batches and logs once it gives up.
+// Messages it can't even accept (no broker answering for the topic) are kept
+// and resent with the next sample, so a broker outage never stalls or ends
+// the run.
+type kafkaSink struct {
+	w       *kafka.Writer
+	pending []kafka.Message
+}
+
+func openKafka(context.Context) (sink, error) {
+	if len(kafkaBrokers) == 0 {
+		return nil, nil
+	}
+	if kafkaBatch < 1 {
+		return nil, fmt.Errorf("--kafka-batch must be at least 1, got %d", kafkaBatch)
+	}
+	mech, err := kafkaMechanism()
+	if err != nil {
+		return nil, err
+	}
+	var tlsConfig *tls.Config
+	if kafkaTLS || kafkaCA != "" {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		if kafkaCA != "" {
+			pem, err := os.ReadFile(kafkaCA)
+			if err != nil {
+				return nil, err
+			}
+			tlsConfig.RootCAs = x509.NewCertPool()
+			if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("--kafka-ca %s: no certificates found", kafkaCA)
+			}
+		}
+	}
+	w := &kafka.Writer{
+		Addr:         kafka.TCP(kafkaBrokers...),
+		Topic:        kafkaTopic,
+		Balancer:     &kafka.Hash{},
+		BatchSize:    kafkaBatch,
+		BatchTimeout: time.Second,
+		MaxAttempts:  kafkaMaxAttempts,
+		RequiredAcks: kafka.RequireAll,
+		Async:        true,
+		Completion: func(msgs []kafka.Message, err error) {
+			if err != nil {
+				slog.Warn("sink write failed", "sink", "kafka", "messages", len(msgs), "err", err)
+			}
+		},
+		Transport: &kafka.Transport{TLS: tlsConfig, SASL: mech},
+	}
+	return &kafkaSink{w: w}, nil
+}
+
+// kafkaMechanism builds the --kafka-sasl mechanism, or nil for none.
+func kafkaMechanism() (sasl.Mechanism, error) {
+	switch strings.ToLower(kafkaSASL) {
+	case "":
+		return nil, nil
+	case "plain":
+		return plain.Mechanism{Username: kafkaUser, Password: kafkaPassword}, nil
+	case "scram-sha-256":
+		return scram.Mechanism(scram.SHA256, kafkaUser, kafkaPassword)
+	case "scram-sha-512":
+		return scram.Mechanism(scram.SHA512, kafkaUser, kafkaPassword)
+	}
+	return nil, fmt.Errorf("invalid --kafka-sasl %q: want plain, scram-sha-256 or scram-sha-512", kafkaSASL)
+}
+
+func (k *kafkaSink) name() string { return "kafka" }
+
+func (k *kafkaSink) write(ctx context.Context, snap Snapshot) error {
+	value, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	k.pending = append(k.pending, kafka.Message{Key: []byte(snap.Host), Value: value, Time: snap.Timestamp})
+	if n := len(k.pending) - kafkaMaxPending; n > 0 {
+		k.pending = k.pending[n:] // drop the oldest
+	}
+	ctx, cancel := context.WithTimeout(ctx, kafkaTimeout)
+	defer cancel()
+	return k.flush(ctx)
+}
+
+func (k *kafkaSink) flush(ctx context.Context) error {
+	if len(k.pending) == 0 {
+		return nil
+	}
+	if err := k.w.WriteMessages(ctx, k.pending...); err != nil {
+		return fmt.Errorf("%w (%d snapshots queued for retry)", err, len(k.pending))
+	}
+	k.pending = nil
+	return nil
+}
+
+// close hands any held-back snapshots to the writer, then waits for it to
+// deliver everything still batched. The run's context is usually cancelled
+// by now, so the final flush gets its own deadline.
+func (k *kafkaSink) close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), kafkaTimeout)
+	defer cancel()
+	err := k.flush(ctx)
+	return errors.Join(err, k.w.Close())
+}
+
+func init() {
+	registerSink(openKafka)
+	collectCmd.Flags().StringSliceVar(&kafkaBrokers, "kafka-brokers", nil, "produce each snapshot as JSON to these Kafka brokers (comma-separated host:port)")
+	collectCmd.Flags().StringVar(&kafkaTopic, "kafka-topic", "gostats", "Kafka topic for --kafka-brokers; messages are keyed by host name")
+	collectCmd.Flags().IntVar(&kafkaBatch, "kafka-batch", 100, "send Kafka messages in batches of up to N (a partial batch goes out after 1s)")
+	collectCmd.Flags().BoolVar(&kafkaTLS, "kafka-tls", false, "connect to the Kafka brokers over TLS")
+	collectCmd.Flags().StringVar(&kafkaCA, "kafka-ca", "", "PEM CA bundle to verify the Kafka brokers with (implies --kafka-tls)")
+	collectCmd.Flags().StringVar(&kafkaSASL, "kafka-sasl", "", "Kafka SASL mechanism: plain, scram-sha-256 or scram-sha-512")
+	collectCmd.Flags().StringVar(&kafkaUser, "kafka-user", "", "Kafka SASL username")
+	collectCmd.Flags().StringVar(&kafkaPassword, "kafka-password", "", "Kafka SASL password")
+}
@@ -0,0 +1,7 @@
+package cmd
+
+var kernelStats bool
+
+func init() {
+	collectCmd.Flags().BoolVar(&kernelStats, "kernel-stats", false, "report context switch and interrupt counters and rates, like vmstat's cs and in (Linux)")
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// readKernelStats returns the cumulative context switch and interrupt
+// counts since boot from the ctxt and intr lines of /proc/stat.
+func readKernelStats() (ctxt, intr uint64, err error) {
+	f, err := os.Open("/proc/stat")
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	// The intr line lists every IRQ and can outgrow the default buffer
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "ctxt":
+			ctxt, err = strconv.ParseUint(fields[1], 10, 64)
+		case "intr":
+			// The first number is the total; the rest are per IRQ
+			intr, err = strconv.ParseUint(fields[1], 10, 64)
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	return ctxt, intr, sc.Err()
+}
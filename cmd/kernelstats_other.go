@@ -0,0 +1,8 @@
+//go:build !linux
+
+package cmd
+
+// readKernelStats reports nothing: /proc/stat is Linux-only.
+func readKernelStats() (ctxt, intr uint64, err error) {
+	return 0, 0, errUnsupported
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	labelFlags []string
+	labels     map[string]string
+)
+
+// labelKey is the Prometheus label-name syntax, which every output format
+// can carry unescaped.
+var labelKey = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// resolveLabels parses the --label flags into labels.
+func resolveLabels() error {
+	labels = nil
+	for _, kv := range labelFlags {
+		k, v, ok := strings.Cut(kv, "=")
+		switch {
+		case !ok:
+			return fmt.Errorf("invalid --label %q: want key=value", kv)
+		case !labelKey.MatchString(k):
+			return fmt.Errorf("invalid --label key %q: use letters, digits and underscores, not starting with a digit", k)
+		case k == "host" || k == "os":
+			return fmt.Errorf("invalid --label key %q: reserved for the built-in tag", k)
+		case v == "":
+			return fmt.Errorf("invalid --label %q: value is empty", kv)
+		}
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[k] = v
+	}
+	return nil
+}
+
+// sortedLabels returns the label keys in a stable order for tag sets.
+func sortedLabels(m map[string]string) []string {
+	return slices.Sorted(maps.Keys(m))
+}
+
+func init() {
+	collectCmd.Flags().StringArrayVar(&labelFlags, "label", nil, "add a key=value label to every snapshot (repeatable; also a tag in influx, prometheus and statsd output)")
+}
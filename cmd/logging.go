@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+)
+
+var logLevel string
+
+// setupLogging installs a --log-level handler as slog's default. Samples
+// may be going to stdout, so diagnostics always go to stderr.
+func setupLogging() error {
+	var lvl slog.Level
+	switch logLevel {
+	case "quiet":
+		lvl = slog.LevelError
+	case "info":
+		lvl = slog.LevelInfo
+	case "debug":
+		lvl = slog.LevelDebug
+	default:
+		return fmt.Errorf("unknown --log-level %q (want quiet, info or debug)", logLevel)
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: lvl})))
+	return nil
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "stderr diagnostics: quiet (errors only), info (adds warnings) or debug (adds failed collectors)")
+}
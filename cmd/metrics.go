@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+)
+
+// metricValue is one named scalar reading from a Snapshot. Names match the
+// JSON keys, with per-instance values qualified as key[instance].
+type metricValue struct {
+	Name  string
+	Value float64
+}
+
+// metricValues flattens the headline numeric metrics of s in a stable
+// order, skipping anything that wasn't collected. It feeds the features that
+// track metrics across samples (summary statistics and the like).
+func metricValues(s Snapshot) []metricValue {
+	var out []metricValue
+	add := func(name string, v *float64) {
+		if v != nil {
+			out = append(out, metricValue{name, *v})
+		}
+	}
+	add("cpu_percent", s.CPUPercent)
+	add("cpu_max_percent", s.CPUMaxPercent)
+	if s.CPUInfo != nil {
+		add("cpu_info.cur_mhz", s.CPUInfo.CurMHz)
+	}
+	add("load1", s.Load1)
+	add("load_per_core", s.LoadPerCore)
+	add("mem_used_pct", s.MemUsedPct)
+	add("swap_used_pct", s.SwapUsedPct)
+	add("ctx_switches_per_sec", s.CtxSwitchRate)
+	add("interrupts_per_sec", s.InterruptRate)
+	add("fds_used_pct", s.FDsUsedPct)
+	if s.PSI != nil {
+		for _, res := range s.PSI.resources() {
+			if res.r == nil {
+				continue
+			}
+			add("psi."+res.name+".some.avg10", &res.r.Some.Avg10)
+			if res.r.Full != nil {
+				add("psi."+res.name+".full.avg10", &res.r.Full.Avg10)
+			}
+		}
+	}
+	if s.Cgroup != nil {
+		add("cgroup.mem_used_pct", s.Cgroup.MemUsedPct)
+		add("cgroup.cpu_percent", s.Cgroup.CPUPercent)
+	}
+	for _, d := range s.Disks {
+		add("disk_used_pct["+d.Path+"]", &d.UsedPct)
+		add("disk_inodes_used_pct["+d.Path+"]", d.InodesUsedPct)
+	}
+	for _, g := range s.GPUs {
+		i := strconv.Itoa(g.Index)
+		add("gpu_util_pct["+i+"]", g.UtilPct)
+		add("gpu_mem_used_pct["+i+"]", g.MemUsedPct)
+		add("gpu_temp_c["+i+"]", g.TempC)
+	}
+	for _, k := range slices.Sorted(maps.Keys(s.Custom)) {
+		add("custom."+k, ptr(s.Custom[k]))
+	}
+	add("net_rate_in_bps", s.NetRateInBps)
+	add("net_rate_out_bps", s.NetRateOutBps)
+	add("net_err_in_per_sec", s.NetErrInRate)
+	add("net_err_out_per_sec", s.NetErrOutRate)
+	add("net_drop_in_per_sec", s.NetDropInRate)
+	add("net_drop_out_per_sec", s.NetDropOutRate)
+	return out
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/shirou/gopsutil/v4/disk"
+)
+
+var (
+	excludeFS []string
+	allDisks  bool
+
+	// discoveredMounts are the --all-disks paths, whose read failures are
+	// expected (permissions, dead network mounts) and only logged.
+	discoveredMounts map[string]bool
+
+	// lostDisks are --disk-path targets that were fine at startup but are
+	// failing now, so each outage is logged once rather than every sample.
+	lostDisksMu sync.Mutex
+	lostDisks   = map[string]bool{}
+)
+
+// defaultExcludeFS are filesystems that hold no real storage, or mirror
+// storage reported elsewhere (overlay, squashfs images).
+var defaultExcludeFS = []string{"tmpfs", "devtmpfs", "overlay", "squashfs", "ramfs", "nsfs", "autofs"}
+
+// realMounts lists mountpoints of real storage: partitions whose type
+// isn't in --exclude-fs, keeping only the first mount of each device so
+// bind mounts and repeated mounts aren't reported twice.
+func realMounts(ctx context.Context) ([]string, error) {
+	parts, err := disk.PartitionsWithContext(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	seen := map[string]bool{}
+	for _, p := range parts {
+		if slices.Contains(excludeFS, p.Fstype) {
+			continue
+		}
+		if p.Device != "" && p.Device != "none" {
+			if seen[p.Device] {
+				continue
+			}
+			seen[p.Device] = true
+		}
+		out = append(out, p.Mountpoint)
+	}
+	return out, nil
+}
+
+// resolveDisks adds every real mount to the --disk-path targets for
+// --all-disks. Mounts are discovered once, at startup, so the table and CSV
+// columns stay fixed for the whole run.
+func resolveDisks(ctx context.Context) error {
+	if !allDisks {
+		return nil
+	}
+	mounts, err := realMounts(ctx)
+	if err != nil {
+		return fmt.Errorf("--all-disks: %w", err)
+	}
+	discoveredMounts = map[string]bool{}
+	for _, m := range mounts {
+		if !slices.Contains(diskPaths, m) {
+			diskPaths = append(diskPaths, m)
+			discoveredMounts[m] = true
+		}
+	}
+	return nil
+}
+
+// validateDiskPaths checks up front that every --disk-path can be reported
+// on, rather than leaving a typo to show up as a disk that is never there.
+// With --ssh the paths are on the remote host, which checks them itself.
+// It runs after --dry-run has had its say, which reports bad paths instead.
+func validateDiskPaths(ctx context.Context) error {
+	if !groupEnabled(groupDisk) || sshTarget != "" {
+		return nil
+	}
+	for _, p := range diskPaths {
+		if err := checkDiskPath(ctx, p); err != nil {
+			return fmt.Errorf("--disk-path %s: %w", p, err)
+		}
+	}
+	return nil
+}
+
+// checkDiskPath reports why p can't be used for disk usage, if it can't.
+func checkDiskPath(ctx context.Context, p string) error {
+	if _, err := os.Stat(p); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return errors.New("no such file or directory")
+		}
+		return err
+	}
+	if _, err := disk.UsageWithContext(ctx, p); err != nil {
+		return fmt.Errorf("can't read filesystem usage: %w", err)
+	}
+	return nil
+}
+
+// noteDiskPath logs when a --disk-path stops answering mid-run, and when it
+// comes back. In between the sample carries the error for that path and
+// reports the other disks as usual.
+func noteDiskPath(p string, err error) {
+	lostDisksMu.Lock()
+	defer lostDisksMu.Unlock()
+	switch {
+	case err != nil && !lostDisks[p]:
+		lostDisks[p] = true
+		slog.Warn("disk path unavailable; omitting it until it returns", "path", p, "err", err)
+	case err == nil && lostDisks[p]:
+		delete(lostDisks, p)
+		slog.Info("disk path available again", "path", p)
+	}
+}
+
+func init() {
+	collectCmd.Flags().BoolVar(&allDisks, "all-disks", false, "report usage for every mounted filesystem not excluded by --exclude-fs, in addition to any --disk-path")
+	collectCmd.Flags().StringSliceVar(&excludeFS, "exclude-fs", defaultExcludeFS, "filesystem types to skip when discovering mounts (comma-separated)")
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+var (
+	mqttBroker   string
+	mqttTopic    string
+	mqttQoS      int
+	mqttUser     string
+	mqttPassword string
+)
+
+// mqttTimeout bounds connecting and each publish so a dead broker doesn't
+// stall the sampling loop.
+const mqttTimeout = 5 * time.Second
+
+// mqttSink publishes each snapshot's JSON to --mqtt-topic. The client
+// reconnects on its own after a dropped connection; publishes in the
+// meantime fail with a warning.
+type mqttSink struct {
+	client mqtt.Client
+}
+
+func openMQTT(ctx context.Context) (sink, error) {
+	if mqttBroker == "" {
+		return nil, nil
+	}
+	if mqttQoS < 0 || mqttQoS > 2 {
+		return nil, fmt.Errorf("--mqtt-qos must be 0, 1 or 2, got %d", mqttQoS)
+	}
+	opts := mqtt.NewClientOptions().
+		AddBroker(mqttBroker).
+		SetClientID(fmt.Sprintf("gostats-%s-%d", localHostname(), os.Getpid())).
+		SetUsername(mqttUser).
+		SetPassword(mqttPassword).
+		SetConnectTimeout(mqttTimeout).
+		SetAutoReconnect(true).
+		// Keep trying in the background if the broker is down at startup
+		// rather than refusing to run.
+		SetConnectRetry(true)
+	c := mqtt.NewClient(opts)
+	if t := c.Connect(); t.WaitTimeout(mqttTimeout) && t.Error() != nil {
+		return nil, fmt.Errorf("mqtt: %s: %w", mqttBroker, t.Error())
+	}
+	return &mqttSink{client: c}, nil
+}
+
+func (m *mqttSink) name() string { return "mqtt" }
+
+func (m *mqttSink) write(_ context.Context, snap Snapshot) error {
+	if !m.client.IsConnectionOpen() {
+		return fmt.Errorf("not connected to %s; retrying in the background", mqttBroker)
+	}
+	payload, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	t := m.client.Publish(mqttTopicFor(snap), byte(mqttQoS), false, payload)
+	if !t.WaitTimeout(mqttTimeout) {
+		return fmt.Errorf("publish to %s timed out", mqttBroker)
+	}
+	return t.Error()
+}
+
+// mqttTopicFor expands {host} in --mqtt-topic.
+func mqttTopicFor(s Snapshot) string {
+	return strings.ReplaceAll(mqttTopic, "{host}", s.Host)
+}
+
+func (m *mqttSink) close() error {
+	m.client.Disconnect(250)
+	return nil
+}
+
+func init() {
+	registerSink(openMQTT)
+	collectCmd.Flags().StringVar(&mqttBroker, "mqtt-broker", "", "publish each snapshot as JSON to this MQTT broker (e.g. tcp://host:1883)")
+	collectCmd.Flags().StringVar(&mqttTopic, "mqtt-topic", "gostats/{host}", "MQTT topic for --mqtt-broker; {host} is replaced by the host name")
+	collectCmd.Flags().IntVar(&mqttQoS, "mqtt-qos", 0, "MQTT QoS level (0, 1 or 2)")
+	collectCmd.Flags().StringVar(&mqttUser, "mqtt-user", "", "MQTT username")
+	collectCmd.Flags().StringVar(&mqttPassword, "mqtt-password", "", "MQTT password")
+}
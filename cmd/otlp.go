@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+var (
+	otlpEndpoint string
+	otlpInterval time.Duration
+	otlpInsecure bool
+)
+
+// otlpSink exports the most recent sample over OTLP/gRPC. Instruments are
+// observable, so the periodic reader batches at --otlp-interval no matter
+// how fast samples arrive. The provider is built on the first sample, so
+// its resource names the host the way the snapshots do: --hostname, the
+// --ssh host or the --redact pseudonym rather than this machine's name.
+type otlpSink struct {
+	exp      sdkmetric.Exporter
+	provider *sdkmetric.MeterProvider
+	latest   atomic.Pointer[Snapshot]
+}
+
+func openOTLP(ctx context.Context) (sink, error) {
+	if otlpEndpoint == "" {
+		return nil, nil
+	}
+	opts := []otlpmetricgrpc.Option{otlpmetricgrpc.WithEndpoint(otlpEndpoint)}
+	if otlpInsecure {
+		opts = append(opts, otlpmetricgrpc.WithInsecure())
+	}
+	exp, err := otlpmetricgrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &otlpSink{exp: exp}, nil
+}
+
+// start builds the provider with snap's host and OS ("linux/debian") as
+// the resource.
+func (s *otlpSink) start(ctx context.Context, snap Snapshot) error {
+	attrs := []attribute.KeyValue{attribute.String("host.name", snap.Host)}
+	if osType, platform, ok := strings.Cut(snap.OS, "/"); ok {
+		attrs = append(attrs,
+			attribute.String("os.type", osType),
+			attribute.String("os.description", platform))
+	}
+	p := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(s.exp, sdkmetric.WithInterval(otlpInterval))),
+		sdkmetric.WithResource(resource.NewSchemaless(attrs...)),
+	)
+	if err := s.register(p.Meter("github.com/qovert/gostats")); err != nil {
+		p.Shutdown(ctx)
+		return err
+	}
+	s.provider = p
+	return nil
+}
+
+func (s *otlpSink) register(m metric.Meter) error {
+	gauges := map[string]string{
+		"gostats.cpu.percent":   "%",
+		"gostats.load.1m":       "1",
+		"gostats.mem.used_pct":  "%",
+		"gostats.swap.used_pct": "%",
+	}
+	obs := map[string]metric.Float64ObservableGauge{}
+	for name, unit := range gauges {
+		g, err := m.Float64ObservableGauge(name, metric.WithUnit(unit))
+		if err != nil {
+			return err
+		}
+		obs[name] = g
+	}
+	disk, err := m.Float64ObservableGauge("gostats.disk.used_pct", metric.WithUnit("%"))
+	if err != nil {
+		return err
+	}
+	netIn, err := m.Int64ObservableCounter("gostats.net.bytes_in", metric.WithUnit("By"))
+	if err != nil {
+		return err
+	}
+	netOut, err := m.Int64ObservableCounter("gostats.net.bytes_out", metric.WithUnit("By"))
+	if err != nil {
+		return err
+	}
+
+	insts := []metric.Observable{disk, netIn, netOut}
+	for _, g := range obs {
+		insts = append(insts, g)
+	}
+	_, err = m.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		snap := s.latest.Load()
+		if snap == nil {
+			return nil
+		}
+		gauge := func(name string, v *float64) {
+			if v != nil {
+				o.ObserveFloat64(obs[name], *v)
+			}
+		}
+		gauge("gostats.cpu.percent", snap.CPUPercent)
+		gauge("gostats.load.1m", snap.Load1)
+		gauge("gostats.mem.used_pct", snap.MemUsedPct)
+		gauge("gostats.swap.used_pct", snap.SwapUsedPct)
+		for _, d := range snap.Disks {
+			o.ObserveFloat64(disk, d.UsedPct, metric.WithAttributes(attribute.String("path", d.Path)))
+		}
+		if snap.NetBytesIn != nil {
+			o.ObserveInt64(netIn, int64(*snap.NetBytesIn))
+		}
+		if snap.NetBytesOut != nil {
+			o.ObserveInt64(netOut, int64(*snap.NetBytesOut))
+		}
+		return nil
+	}, insts...)
+	return err
+}
+
+func (s *otlpSink) name() string { return "otlp" }
+
+func (s *otlpSink) write(ctx context.Context, snap Snapshot) error {
+	s.latest.Store(&snap)
+	if s.provider == nil {
+		return s.start(ctx, snap)
+	}
+	return nil
+}
+
+// close shuts the provider down, which collects and exports one final batch
+// so the last samples before ctrl-c aren't dropped.
+func (s *otlpSink) close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if s.provider == nil {
+		return s.exp.Shutdown(ctx)
+	}
+	return s.provider.Shutdown(ctx)
+}
+
+func init() {
+	registerSink(openOTLP)
+	collectCmd.Flags().StringVar(&otlpEndpoint, "otlp-endpoint", "", "export metrics over OTLP/gRPC to this host:port (e.g. localhost:4317)")
+	collectCmd.Flags().DurationVar(&otlpInterval, "otlp-interval", 10*time.Second, "how often to export to --otlp-endpoint")
+	collectCmd.Flags().BoolVar(&otlpInsecure, "otlp-insecure", false, "connect to --otlp-endpoint without TLS")
+}
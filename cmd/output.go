@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var (
+	outputPath  string
+	rotateSize  string
+	rotateCount int
+	gzipOut     bool
+)
+
+// openOutput returns where samples should be written: stdout, or the
+// --output file wrapped for rotation. Closing stdout is a no-op.
+func openOutput(outFmt string) (io.WriteCloser, error) {
+	if outputPath == "" {
+		return nopCloser{os.Stdout}, nil
+	}
+	maxSize, err := parseSize(rotateSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --rotate-size: %w", err)
+	}
+	// Rotated files start with the format's header, just like the first one
+	hdr := newEmitter(nil, outFmt, true).headerText()
+	gz := gzipOut || strings.HasSuffix(outputPath, ".gz")
+	return openRotatingFile(outputPath, maxSize, rotateCount, hdr, gz)
+}
+
+type nopCloser struct{ io.Writer }
+
+func (nopCloser) Close() error { return nil }
+
+// rotatingFile appends to path and, once a write would take it past maxSize,
+// shifts path to path.1, path.1 to path.2 and so on, keeping at most keep
+// old files. Writes go straight to the file, so nothing is lost on a crash.
+//
+// With compress set each file is its own gzip stream, flushed after every
+// write; maxSize then applies to the compressed size.
+type rotatingFile struct {
+	path     string
+	maxSize  int64
+	keep     int
+	header   []byte
+	compress bool
+
+	f    *os.File
+	gz   *gzip.Writer
+	size int64
+
+	// appending is set when path already had content at startup, so it
+	// already begins with the header.
+	appending bool
+}
+
+func openRotatingFile(path string, maxSize int64, keep int, header []byte, compress bool) (*rotatingFile, error) {
+	r := &rotatingFile{path: path, maxSize: maxSize, keep: keep, header: header, compress: compress}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	r.appending = r.size > 0
+	return r, nil
+}
+
+// resumed reports whether the file was appended to rather than started.
+func (r *rotatingFile) resumed() bool { return r.appending }
+
+func (r *rotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	r.f, r.size = f, fi.Size()
+	if r.compress {
+		// Appending to an existing archive adds a new gzip member, which
+		// gunzip reads back as one continuous stream.
+		r.gz = gzip.NewWriter(fileSink{r})
+	}
+	return nil
+}
+
+// fileSink counts bytes as they reach the underlying file, so the rotation
+// size reflects what's on disk even when compressing.
+type fileSink struct{ r *rotatingFile }
+
+func (s fileSink) Write(p []byte) (int, error) {
+	n, err := s.r.f.Write(p)
+	s.r.size += int64(n)
+	return n, err
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	if r.maxSize > 0 && r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	return r.write(p)
+}
+
+func (r *rotatingFile) write(p []byte) (int, error) {
+	if r.gz == nil {
+		return fileSink{r}.Write(p)
+	}
+	n, err := r.gz.Write(p)
+	if err != nil {
+		return n, err
+	}
+	return n, r.gz.Flush()
+}
+
+// rotatedName returns the name of the i-th old file, keeping a .gz suffix
+// last so rotated archives are still recognized by gzip tools.
+func (r *rotatingFile) rotatedName(i int) string {
+	if base, ok := strings.CutSuffix(r.path, ".gz"); ok && r.compress {
+		return fmt.Sprintf("%s.%d.gz", base, i)
+	}
+	return fmt.Sprintf("%s.%d", r.path, i)
+}
+
+func (r *rotatingFile) rotate() error {
+	if err := r.closeFile(); err != nil {
+		return err
+	}
+	if r.keep > 0 {
+		os.Remove(r.rotatedName(r.keep))
+		for i := r.keep - 1; i >= 1; i-- {
+			os.Rename(r.rotatedName(i), r.rotatedName(i+1))
+		}
+		if err := os.Rename(r.path, r.rotatedName(1)); err != nil {
+			return err
+		}
+	} else if err := os.Remove(r.path); err != nil {
+		return err
+	}
+	if err := r.open(); err != nil {
+		return err
+	}
+	if len(r.header) > 0 {
+		_, err := r.write(r.header)
+		return err
+	}
+	return nil
+}
+
+func (r *rotatingFile) closeFile() error {
+	if r.gz != nil {
+		if err := r.gz.Close(); err != nil {
+			r.f.Close()
+			return err
+		}
+	}
+	return r.f.Close()
+}
+
+// Close finishes the gzip stream, if any, and closes the file. It runs on
+// both normal exit and ctrl-c, so archives are never left truncated.
+func (r *rotatingFile) Close() error {
+	return r.closeFile()
+}
+
+// parseSize parses sizes like "512KB", "100MB" or "1GB" (multiples of 1024).
+// A bare number is bytes and "" or "0" disables rotation.
+func parseSize(s string) (int64, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if s == "" {
+		return 0, nil
+	}
+	mult := int64(1)
+	for _, u := range []struct {
+		suffix string
+		mult   int64
+	}{{"KB", 1 << 10}, {"MB", 1 << 20}, {"GB", 1 << 30}, {"K", 1 << 10}, {"M", 1 << 20}, {"G", 1 << 30}, {"B", 1}} {
+		if strings.HasSuffix(s, u.suffix) {
+			s, mult = strings.TrimSuffix(s, u.suffix), u.mult
+			break
+		}
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("bad size %q", s)
+	}
+	return n * mult, nil
+}
+
+func init() {
+	collectCmd.Flags().StringVar(&outputPath, "output", "", "write samples to this file instead of stdout (or as well, with --output-format)")
+	collectCmd.Flags().StringVar(&rotateSize, "rotate-size", "", "rotate --output once it would exceed this size (e.g. 100MB)")
+	collectCmd.Flags().IntVar(&rotateCount, "rotate-count", 5, "number of rotated --output files to keep")
+	collectCmd.Flags().BoolVar(&gzipOut, "gzip", false, "gzip-compress --output (implied by a .gz suffix)")
+}
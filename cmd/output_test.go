@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseSize(t *testing.T) {
+	for in, want := range map[string]int64{
+		"":       0, // rotation off
+		"0":      0,
+		"4096":   4096,
+		"512B":   512,
+		"64k":    64 << 10,
+		"512KB":  512 << 10,
+		"100MB":  100 << 20,
+		" 10 mb": 10 << 20,
+		"1G":     1 << 30,
+		"8GB":    8 << 30,
+	} {
+		if got, err := parseSize(in); err != nil || got != want {
+			t.Errorf("parseSize(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	// No fractions, terabytes or SI spellings: those are typos until asked for
+	for _, in := range []string{"1.5GB", "-1MB", "MB", "10TB", "100MiB", "ten"} {
+		if n, err := parseSize(in); err == nil {
+			t.Errorf("parseSize(%q) = %d, want an error", in, n)
+		}
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.csv")
+	const hdr = "ts,cpu\n"
+	r, err := openRotatingFile(path, 40, 2, []byte(hdr), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.resumed() {
+		t.Error("a new file reports resumed")
+	}
+	// The caller writes the first header; rotation writes the later ones.
+	// 40 bytes hold the header and four 8-byte rows.
+	io.WriteString(r, hdr)
+	var rows []string
+	for i := 1; i <= 13; i++ {
+		row := fmt.Sprintf("%02d,%4.1f\n", i, float64(i)*1.5)
+		rows = append(rows, row)
+		if _, err := io.WriteString(r, row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Rows 1-4 were rotated out: only two old files are kept
+	for name, want := range map[string]string{
+		"stats.csv.2": hdr + strings.Join(rows[4:8], ""),
+		"stats.csv.1": hdr + strings.Join(rows[8:12], ""),
+		"stats.csv":   hdr + rows[12],
+	} {
+		if got := readFile(t, filepath.Join(filepath.Dir(path), name)); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("stats.csv.3 was kept: %v", err)
+	}
+}
+
+func TestRotatingFileResumes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.csv")
+	if err := os.WriteFile(path, []byte("ts,cpu\n1,10.5\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := openRotatingFile(path, 0, 5, []byte("ts,cpu\n"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.resumed() {
+		t.Error("appending to an existing file doesn't report resumed")
+	}
+	io.WriteString(r, "2,11.0\n")
+	r.Close()
+	if got := readFile(t, path); got != "ts,cpu\n1,10.5\n2,11.0\n" {
+		t.Errorf("stats.csv = %q", got)
+	}
+}
+
+func TestRotatingFileGzip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "stats.json.gz")
+	// Each write is flushed, so even tiny limits rotate per write
+	r, err := openRotatingFile(path, 1, 3, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{`{"n":1}` + "\n", `{"n":2}` + "\n"} {
+		if _, err := io.WriteString(r, line); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r.Close()
+
+	// The number goes before .gz so gzip tools still recognize old files
+	for name, want := range map[string]string{"stats.json.1.gz": `{"n":1}`, "stats.json.gz": `{"n":2}`} {
+		f, err := os.Open(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		b, err := io.ReadAll(zr)
+		f.Close()
+		if err != nil || strings.TrimSpace(string(b)) != want {
+			t.Errorf("%s = %q, %v; want %s", name, b, err, want)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	percentileFlags []string
+	pctWindowSize   int
+
+	// pcts are the parsed --percentiles, in the order given
+	pcts []float64
+)
+
+// Percentiles holds rolling percentiles over the last Window samples, keyed
+// by the requested name ("p95"). Until the window fills, Window is the
+// number of samples seen so far.
+type Percentiles struct {
+	Window        int                `json:"window"`
+	CPUPercent    map[string]float64 `json:"cpu_percent,omitempty"`
+	NetRateInBps  map[string]float64 `json:"net_rate_in_bps,omitempty"`
+	NetRateOutBps map[string]float64 `json:"net_rate_out_bps,omitempty"`
+}
+
+// resolvePercentiles parses --percentiles entries such as p50, p99.9 or 95.
+func resolvePercentiles() error {
+	pcts = nil
+	if pctWindowSize < 1 {
+		return fmt.Errorf("--window must be at least 1, got %d", pctWindowSize)
+	}
+	for _, f := range percentileFlags {
+		v, err := strconv.ParseFloat(strings.TrimPrefix(strings.ToLower(f), "p"), 64)
+		if err != nil || v <= 0 || v > 100 {
+			return fmt.Errorf("invalid --percentiles entry %q (want e.g. p50, p95, p99)", f)
+		}
+		pcts = append(pcts, v)
+	}
+	return nil
+}
+
+// pctName is the key and column label for percentile p: 95 -> "p95".
+func pctName(p float64) string {
+	return "p" + strconv.FormatFloat(p, 'f', -1, 64)
+}
+
+// pctTracker keeps the last --window readings of each tracked metric, so
+// memory stays bounded however long the stream runs. A nil *pctTracker
+// ignores everything, like a nil *smoother.
+type pctTracker struct {
+	size               int
+	n                  int
+	cpu, netIn, netOut window
+}
+
+func newPctTracker() *pctTracker {
+	if len(pcts) == 0 {
+		return nil
+	}
+	return &pctTracker{size: pctWindowSize}
+}
+
+// apply records s and attaches the current percentiles to it.
+func (t *pctTracker) apply(s *Snapshot) {
+	if t == nil {
+		return
+	}
+	t.n = min(t.n+1, t.size)
+	t.cpu.record(s.CPUPercent, t.size)
+	t.netIn.record(s.NetRateInBps, t.size)
+	t.netOut.record(s.NetRateOutBps, t.size)
+	s.Percentiles = &Percentiles{
+		Window:        t.n,
+		CPUPercent:    t.cpu.percentiles(),
+		NetRateInBps:  t.netIn.percentiles(),
+		NetRateOutBps: t.netOut.percentiles(),
+	}
+}
+
+// percentiles computes every --percentiles value over the window, or nil
+// if it holds no readings yet.
+func (w *window) percentiles() map[string]float64 {
+	if len(w.vals) == 0 {
+		return nil
+	}
+	sorted := slices.Clone(w.vals)
+	slices.Sort(sorted)
+	out := make(map[string]float64, len(pcts))
+	for _, p := range pcts {
+		out[pctName(p)] = percentile(sorted, p)
+	}
+	return out
+}
+
+func percentilesHeader() string {
+	cols := make([]string, len(pcts))
+	for i, p := range pcts {
+		n := strings.ToUpper(pctName(p))
+		cols[i] = n + "_CPU%\t" + n + "_NET_IN/OUT"
+	}
+	return strings.Join(cols, "\t")
+}
+
+// humanColumns renders one CPU and one net in/out cell per percentile.
+func (p *Percentiles) humanColumns() string {
+	cols := make([]string, len(pcts))
+	for i, pc := range pcts {
+		name := pctName(pc)
+		cell := func(m map[string]float64, prec int) string {
+			v, ok := m[name]
+			if !ok {
+				return "-"
+			}
+			return fmtFloat(v, prec)
+		}
+		if p == nil {
+			cols[i] = "-\t-/-"
+			continue
+		}
+		cols[i] = cell(p.CPUPercent, 1) + "\t" + cell(p.NetRateInBps, 0) + "/" + cell(p.NetRateOutBps, 0)
+	}
+	return strings.Join(cols, "\t")
+}
+
+func init() {
+	collectCmd.Flags().StringSliceVar(&percentileFlags, "percentiles", nil, "also report rolling percentiles of CPU% and net rates, e.g. p50,p95,p99 (streaming only)")
+	collectCmd.Flags().IntVar(&pctWindowSize, "window", 60, "number of recent samples --percentiles are computed over")
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+)
+
+// usePercentiles sets --percentiles and --window for one test.
+func usePercentiles(t *testing.T, window int, flags ...string) error {
+	t.Helper()
+	oldFlags, oldWindow := percentileFlags, pctWindowSize
+	t.Cleanup(func() { percentileFlags, pctWindowSize, pcts = oldFlags, oldWindow, nil })
+	percentileFlags, pctWindowSize = flags, window
+	return resolvePercentiles()
+}
+
+func TestResolvePercentiles(t *testing.T) {
+	if err := usePercentiles(t, 60, "p50", "P99.9", "95"); err != nil {
+		t.Fatal(err)
+	}
+	if want := []float64{50, 99.9, 95}; !slices.Equal(pcts, want) {
+		t.Errorf("pcts = %v, want %v in the order given", pcts, want)
+	}
+	if got := pctName(pcts[1]); got != "p99.9" {
+		t.Errorf("pctName(99.9) = %q", got)
+	}
+	for _, bad := range [][]string{{"p0"}, {"p100.5"}, {"median"}, {"p-5"}, {""}} {
+		if err := usePercentiles(t, 60, bad...); err == nil {
+			t.Errorf("--percentiles %q accepted", bad)
+		}
+	}
+	if err := usePercentiles(t, 0, "p95"); err == nil {
+		t.Error("--window 0 accepted")
+	}
+}
+
+// TestPctTrackerWindow streams CPU readings through a 5-sample window and
+// checks the nearest-rank percentiles as old readings fall out of it.
+func TestPctTrackerWindow(t *testing.T) {
+	if err := usePercentiles(t, 5, "p50", "p100"); err != nil {
+		t.Fatal(err)
+	}
+	tr := newPctTracker()
+	var last Snapshot
+	for _, cpu := range []float64{90, 10, 20, 30, 40, 50, 60} {
+		last = Snapshot{CPUPercent: ptr(cpu)}
+		tr.apply(&last)
+	}
+	p := last.Percentiles
+	// The window now holds the last five readings, 20 through 60
+	if p.Window != 5 {
+		t.Errorf("Window = %d, want 5", p.Window)
+	}
+	if p.CPUPercent["p50"] != 40 || p.CPUPercent["p100"] != 60 {
+		t.Errorf("CPU percentiles = %v, want p50 40 and p100 60 once the 90 has left the window", p.CPUPercent)
+	}
+	// Net rates were never collected, so they have no percentiles at all
+	if p.NetRateInBps != nil || p.NetRateOutBps != nil {
+		t.Errorf("net percentiles = %v / %v, want none", p.NetRateInBps, p.NetRateOutBps)
+	}
+}
+
+func TestPctTrackerFilling(t *testing.T) {
+	if err := usePercentiles(t, 60, "p95"); err != nil {
+		t.Fatal(err)
+	}
+	tr := newPctTracker()
+	s := Snapshot{CPUPercent: ptr(12.0), NetRateInBps: ptr(1500.0)}
+	tr.apply(&s)
+	// Until the window fills it reports how many samples it has seen
+	if s.Percentiles.Window != 1 || s.Percentiles.CPUPercent["p95"] != 12 || s.Percentiles.NetRateInBps["p95"] != 1500 {
+		t.Errorf("first sample: %+v", s.Percentiles)
+	}
+}
+
+func TestPctTrackerOff(t *testing.T) {
+	if err := usePercentiles(t, 60); err != nil {
+		t.Fatal(err)
+	}
+	tr := newPctTracker()
+	s := Snapshot{CPUPercent: ptr(50.0)}
+	tr.apply(&s) // a nil tracker is a no-op
+	if tr != nil || s.Percentiles != nil {
+		t.Errorf("without --percentiles: tracker %v, percentiles %+v", tr, s.Percentiles)
+	}
+}
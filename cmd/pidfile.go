@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/shirou/gopsutil/v4/process"
+)
+
+var pidFile string
+
+// writePidfile records our PID in path, refusing if it names another live
+// process; a stale file left by a crash is replaced. The returned func
+// removes the file and is meant to be deferred, which covers SIGTERM too
+// since the signal only cancels the run's context.
+func writePidfile(ctx context.Context, path string) (func(), error) {
+	if b, err := os.ReadFile(path); err == nil {
+		if pid, err := strconv.Atoi(strings.TrimSpace(string(b))); err == nil && pid != os.Getpid() {
+			if alive, _ := process.PidExistsWithContext(ctx, int32(pid)); alive {
+				return nil, fmt.Errorf("--pidfile %s: already running as pid %d", path, pid)
+			}
+		}
+	}
+	if err := os.WriteFile(path, []byte(strconv.Itoa(os.Getpid())+"\n"), 0o644); err != nil {
+		return nil, fmt.Errorf("--pidfile: %w", err)
+	}
+	return func() { os.Remove(path) }, nil
+}
+
+func init() {
+	collectCmd.Flags().StringVar(&pidFile, "pidfile", "", "write the process ID to this file and remove it on exit (for running under systemd, runit and the like)")
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+)
+
+// precision is --precision; negative keeps each field's own default.
+var precision int
+
+// fmtFloat formats a table value with prec decimals, or --precision when set.
+func fmtFloat(v float64, prec int) string {
+	if precision >= 0 {
+		prec = precision
+	}
+	return strconv.FormatFloat(v, 'f', prec, 64)
+}
+
+// roundJSON rewrites every non-integer number in encoded JSON to at most
+// --precision decimals. Integer literals (byte counters, PIDs) are left as
+// they are so large values never pass through a float64.
+func roundJSON(b []byte) []byte {
+	if precision < 0 {
+		return b
+	}
+	scale := math.Pow10(precision)
+	var out bytes.Buffer
+	out.Grow(len(b))
+	inString := false
+	for i := 0; i < len(b); i++ {
+		c := b[i]
+		if inString {
+			out.WriteByte(c)
+			switch c {
+			case '\\':
+				i++
+				if i < len(b) {
+					out.WriteByte(b[i])
+				}
+			case '"':
+				inString = false
+			}
+			continue
+		}
+		if c == '"' {
+			inString = true
+			out.WriteByte(c)
+			continue
+		}
+		if c != '-' && (c < '0' || c > '9') {
+			out.WriteByte(c)
+			continue
+		}
+		j := i
+		isFloat := false
+		for j < len(b) && bytes.IndexByte([]byte("+-0123456789.eE"), b[j]) >= 0 {
+			isFloat = isFloat || b[j] == '.' || b[j] == 'e' || b[j] == 'E'
+			j++
+		}
+		num := b[i:j]
+		if isFloat {
+			if f, err := strconv.ParseFloat(string(num), 64); err == nil {
+				num = strconv.AppendFloat(nil, math.Round(f*scale)/scale, 'f', -1, 64)
+			}
+		}
+		out.Write(num)
+		i = j - 1
+	}
+	return out.Bytes()
+}
+
+func init() {
+	collectCmd.Flags().IntVar(&precision, "precision", -1, "decimal places for numbers in human and JSON output (default: per field in tables, full precision in JSON)")
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+func setPrecision(t *testing.T, p int) {
+	t.Helper()
+	old := precision
+	t.Cleanup(func() { precision = old })
+	precision = p
+}
+
+func TestRoundJSONSnapshot(t *testing.T) {
+	setPrecision(t, 2)
+	s := Snapshot{
+		Timestamp:     time.Date(2026, 3, 1, 12, 0, 0, 123456789, time.UTC),
+		Host:          "web-1.5",
+		OS:            "linux/ubuntu",
+		CPUPercent:    ptr(5.0000000000909495), // as gopsutil reports it
+		CPUPerCore:    []float64{0.333333, 99.999},
+		NetBytesIn:    ptr(uint64(math.MaxUint64)),
+		NetRateInBps:  ptr(1234.5678),
+		NetRateOutBps: ptr(-0.004),
+		Labels:        map[string]string{"version": "1.23456"},
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Ts            string            `json:"ts"`
+		Host          string            `json:"host"`
+		CPUPercent    float64           `json:"cpu_percent"`
+		CPUPerCore    []float64         `json:"cpu_per_core"`
+		NetBytesIn    uint64            `json:"net_bytes_in"`
+		NetRateInBps  float64           `json:"net_rate_in_bps"`
+		NetRateOutBps float64           `json:"net_rate_out_bps"`
+		Labels        map[string]string `json:"labels"`
+	}
+	if err := json.Unmarshal(roundJSON(b), &got); err != nil {
+		t.Fatalf("roundJSON broke the JSON: %v\n%s", err, roundJSON(b))
+	}
+	if got.CPUPercent != 5 || got.CPUPerCore[0] != 0.33 || got.CPUPerCore[1] != 100 || got.NetRateInBps != 1234.57 || got.NetRateOutBps != 0 {
+		t.Errorf("floats not rounded to 2 places: %+v", got)
+	}
+	// Counters never go through a float64, which can't hold MaxUint64
+	if got.NetBytesIn != math.MaxUint64 {
+		t.Errorf("net_bytes_in = %d, want %d", got.NetBytesIn, uint64(math.MaxUint64))
+	}
+	// Digits inside strings are not numbers
+	if got.Ts != "2026-03-01T12:00:00.123456789Z" || got.Host != "web-1.5" || got.Labels["version"] != "1.23456" {
+		t.Errorf("strings were rewritten: %+v", got)
+	}
+}
+
+func TestRoundJSONLiterals(t *testing.T) {
+	setPrecision(t, 1)
+	for in, want := range map[string]string{
+		`[1.5e-7,2.25E3,-0.06]`:        `[0,2250,-0.1]`,
+		`{"a":"\"3.14159\"","b":2.75}`: `{"a":"\"3.14159\"","b":2.8}`,
+		`{"a":"back\\","b":0.25}`:      `{"a":"back\\","b":0.3}`,
+		`{"n":10,"m":-3}`:              `{"n":10,"m":-3}`,
+	} {
+		if got := string(roundJSON([]byte(in))); got != want {
+			t.Errorf("roundJSON(%s) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestRoundJSONOff(t *testing.T) {
+	setPrecision(t, -1)
+	in := []byte(`{"cpu_percent":5.0000000000909495}`)
+	if got := roundJSON(in); string(got) != string(in) {
+		t.Errorf("without --precision got %s", got)
+	}
+	setPrecision(t, 0)
+	if got := fmtFloat(12.345, 2); got != "12" {
+		t.Errorf("fmtFloat at --precision 0 = %q, want 12", got)
+	}
+}
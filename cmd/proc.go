@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/process"
+	"github.com/spf13/cobra"
+)
+
+var (
+	procPID      int32
+	procName     string
+	procChildren bool
+	procInterval time.Duration
+	procCount    int
+	procFormat   string
+	procOnExit   string
+)
+
+// ProcSample is the combined resource use of the watched processes in one
+// sample. With --name or --children every figure is summed across PIDs.
+// Gone marks a sample taken after every watched process had exited.
+type ProcSample struct {
+	Timestamp   time.Time `json:"ts"`
+	PIDs        []int32   `json:"pids"`
+	CPUPercent  float64   `json:"cpu_percent"`
+	RSSMB       float64   `json:"rss_mb"`
+	FDs         int32     `json:"fds"`
+	Threads     int32     `json:"threads"`
+	Connections int       `json:"connections"`
+	Gone        bool      `json:"gone,omitempty"`
+}
+
+// procWatcher keeps a handle per watched PID between samples so each CPU
+// percent covers the time since the previous sample, as procCache does for
+// --top.
+type procWatcher struct {
+	handles map[int32]*process.Process
+}
+
+// targets returns the PIDs to sample: --pid or every process named --name,
+// plus all their descendants with --children.
+func (w *procWatcher) targets(ctx context.Context) ([]int32, error) {
+	var roots []*process.Process
+	if procName != "" {
+		ps, err := process.ProcessesWithContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range ps {
+			if name, err := p.NameWithContext(ctx); err == nil && name == procName {
+				roots = append(roots, p)
+			}
+		}
+	} else if ok, _ := process.PidExistsWithContext(ctx, procPID); ok {
+		p, err := process.NewProcessWithContext(ctx, procPID)
+		if err == nil {
+			roots = append(roots, p)
+		}
+	}
+
+	var pids []int32
+	var walk func(p *process.Process)
+	walk = func(p *process.Process) {
+		if slices.Contains(pids, p.Pid) {
+			return
+		}
+		pids = append(pids, p.Pid)
+		if !procChildren {
+			return
+		}
+		kids, _ := p.ChildrenWithContext(ctx) // an error just means none
+		for _, k := range kids {
+			walk(k)
+		}
+	}
+	for _, p := range roots {
+		walk(p)
+	}
+	slices.Sort(pids)
+	return pids, nil
+}
+
+// sample sums the current readings of every target. Processes that exit
+// mid-read are dropped from the sample; readings that need more privilege
+// than we have (another user's fds) count as zero.
+func (w *procWatcher) sample(ctx context.Context) (ProcSample, error) {
+	s := ProcSample{Timestamp: time.Now()}
+	pids, err := w.targets(ctx)
+	if err != nil {
+		return s, err
+	}
+
+	primed := false
+	for _, pid := range pids {
+		if _, ok := w.handles[pid]; ok {
+			continue
+		}
+		p, err := process.NewProcessWithContext(ctx, pid)
+		if err != nil {
+			continue
+		}
+		p.PercentWithContext(ctx, 0) // seed the CPU baseline
+		w.handles[pid] = p
+		primed = true
+	}
+	for pid := range w.handles {
+		if !slices.Contains(pids, pid) {
+			delete(w.handles, pid)
+		}
+	}
+	if primed {
+		select {
+		case <-ctx.Done():
+			return s, ctx.Err()
+		case <-time.After(cpuWindow):
+		}
+	}
+
+	for _, pid := range pids {
+		p, ok := w.handles[pid]
+		if !ok {
+			continue
+		}
+		pct, err := p.PercentWithContext(ctx, 0)
+		if err != nil {
+			slog.Debug("process exited", "pid", pid, "err", err)
+			delete(w.handles, pid)
+			continue
+		}
+		s.PIDs = append(s.PIDs, pid)
+		s.CPUPercent += pct
+		if mi, err := p.MemoryInfoWithContext(ctx); err == nil {
+			s.RSSMB += float64(mi.RSS) / (1024 * 1024)
+		}
+		if n, err := p.NumFDsWithContext(ctx); err == nil {
+			s.FDs += n
+		}
+		if n, err := p.NumThreadsWithContext(ctx); err == nil {
+			s.Threads += n
+		}
+		if conns, err := p.ConnectionsWithContext(ctx); err == nil {
+			s.Connections += len(conns)
+		}
+	}
+	s.Gone = len(s.PIDs) == 0
+	return s, nil
+}
+
+func procHeader() string {
+	return "TIME\tPIDS\tCPU%\tRSS(MB)\tFDS\tTHREADS\tCONNS"
+}
+
+func (s ProcSample) humanRow() string {
+	pids := "gone"
+	if !s.Gone {
+		parts := make([]string, len(s.PIDs))
+		for i, p := range s.PIDs {
+			parts[i] = strconv.Itoa(int(p))
+		}
+		pids = strings.Join(parts, ",")
+	}
+	return fmt.Sprintf("%s\t%s\t%s\t%s\t%d\t%d\t%d",
+		formatTimestamp(s.Timestamp, "15:04:05"), pids,
+		fmtFloat(s.CPUPercent, 1), fmtFloat(s.RSSMB, 1),
+		s.FDs, s.Threads, s.Connections)
+}
+
+func writeProcSample(w io.Writer, s ProcSample, first bool) error {
+	if procFormat == formatJSON {
+		return json.NewEncoder(w).Encode(s)
+	}
+	text := alignTable(procHeader(), s.humanRow())
+	if !first {
+		_, text, _ = strings.Cut(text, "\n")
+	}
+	_, err := io.WriteString(w, text)
+	return err
+}
+
+var procCmd = &cobra.Command{
+	Use:   "proc",
+	Short: "Report one process's resource use",
+	Long: `proc samples the CPU, memory, file descriptors, threads and open connections
+of a single process (--pid) or of every process with a given name (--name),
+optionally including all their children, and prints one line per sample.
+
+When every watched process has exited, --on-exit exit (the default) prints a
+final "gone" line and exits 0; --on-exit zero keeps reporting zeros, and
+with --name picks the processes up again if they come back.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if (procPID == 0) == (procName == "") {
+			return fmt.Errorf("exactly one of --pid or --name is required")
+		}
+		if procFormat != formatHuman && procFormat != formatJSON {
+			return fmt.Errorf("unknown --format %q (want human or json)", procFormat)
+		}
+		if procOnExit != "exit" && procOnExit != "zero" {
+			return fmt.Errorf("unknown --on-exit %q (want exit or zero)", procOnExit)
+		}
+		if procInterval <= 0 {
+			return fmt.Errorf("--interval must be positive")
+		}
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+
+		w := &procWatcher{handles: map[int32]*process.Process{}}
+		t := time.NewTicker(procInterval)
+		defer t.Stop()
+		for i := 0; procCount <= 0 || i < procCount; i++ {
+			s, err := w.sample(ctx)
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if i == 0 && s.Gone {
+				cmd.SilenceUsage = true
+				return errors.New("no matching process")
+			}
+			if err := writeProcSample(os.Stdout, s, i == 0); err != nil {
+				return err
+			}
+			if s.Gone && procOnExit == "exit" {
+				return nil
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-t.C:
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(procCmd)
+	procCmd.Flags().Int32Var(&procPID, "pid", 0, "process ID to watch")
+	procCmd.Flags().StringVar(&procName, "name", "", "watch every process with this exact name, summed")
+	procCmd.Flags().BoolVar(&procChildren, "children", false, "include all descendants of the watched processes")
+	procCmd.Flags().DurationVar(&procInterval, "interval", 2*time.Second, "sampling interval")
+	procCmd.Flags().IntVar(&procCount, "count", 0, "number of samples (0 = until ctrl-c or the process exits)")
+	procCmd.Flags().StringVar(&procFormat, "format", formatHuman, "output format: human or json")
+	procCmd.Flags().StringVar(&procOnExit, "on-exit", "exit", "when the watched processes are gone: exit (after a final line) or zero (keep reporting zeros)")
+}
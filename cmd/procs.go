@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/shirou/gopsutil/v4/process"
+)
+
+var procCounts bool
+
+// countProcs returns the number of processes, their total thread count and
+// how many are zombies. Processes that exit mid-walk are skipped.
+func countProcs(ctx context.Context) (procs, threads, zombies uint64, err error) {
+	ps, err := process.ProcessesWithContext(ctx)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	for _, p := range ps {
+		st, err := p.StatusWithContext(ctx)
+		if err != nil {
+			continue
+		}
+		procs++
+		if slices.Contains(st, process.Zombie) {
+			zombies++
+		}
+		if n, err := p.NumThreadsWithContext(ctx); err == nil {
+			threads += uint64(n)
+		}
+	}
+	return procs, threads, zombies, nil
+}
+
+// humanProcCounts renders the --procs column as "procs/threads/zombies".
+func (s Snapshot) humanProcCounts() string {
+	if s.ProcCount == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d/%s/%s", *s.ProcCount, optUint(s.ThreadCount), optUint(s.ZombieCount))
+}
+
+func init() {
+	collectCmd.Flags().BoolVar(&procCounts, "procs", false, "count processes, threads and zombies (walks every PID)")
+}
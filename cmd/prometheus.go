@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// promSample is one value of a metric family with its extra labels given as
+// alternating name/value pairs. The host and --label labels are added by the
+// writer.
+type promSample struct {
+	labels []string
+	value  float64
+}
+
+type promWriter struct {
+	b      strings.Builder
+	host   string
+	labels map[string]string // --label pairs, added to every sample
+}
+
+// family writes the HELP/TYPE preamble once followed by every sample.
+// Families with no samples are skipped entirely.
+func (p *promWriter) family(name, typ, help string, samples ...promSample) {
+	if len(samples) == 0 {
+		return
+	}
+	fmt.Fprintf(&p.b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+	for _, s := range samples {
+		p.b.WriteString(name)
+		p.b.WriteString(`{host="`)
+		p.b.WriteString(promEscape(p.host))
+		p.b.WriteByte('"')
+		for _, k := range sortedLabels(p.labels) {
+			fmt.Fprintf(&p.b, `,%s="%s"`, k, promEscape(p.labels[k]))
+		}
+		for i := 0; i+1 < len(s.labels); i += 2 {
+			fmt.Fprintf(&p.b, `,%s="%s"`, s.labels[i], promEscape(s.labels[i+1]))
+		}
+		p.b.WriteString("} ")
+		p.b.WriteString(strconv.FormatFloat(s.value, 'g', -1, 64))
+		p.b.WriteByte('\n')
+	}
+}
+
+func (p *promWriter) gauge(name, help string, v float64) {
+	p.family(name, "gauge", help, promSample{value: v})
+}
+
+func (p *promWriter) optGauge(name, help string, v *float64) {
+	if v != nil {
+		p.gauge(name, help, *v)
+	}
+}
+
+func (p *promWriter) optCounter(name, help string, v *uint64) {
+	if v != nil {
+		p.family(name, "counter", help, promSample{value: float64(*v)})
+	}
+}
+
+// scaled converts an optional integer reading to a float in another unit.
+func scaled(v *uint64, factor float64) *float64 {
+	if v == nil {
+		return nil
+	}
+	return ptr(float64(*v) * factor)
+}
+
+func scaledFloat(v *float64, factor float64) *float64 {
+	if v == nil {
+		return nil
+	}
+	return ptr(*v * factor)
+}
+
+// promEscape escapes a label value per the text exposition format.
+func promEscape(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+// writePrometheus renders s in the Prometheus text exposition format, suitable
+// for the node_exporter textfile collector or an HTTP /metrics handler.
+func writePrometheus(w io.Writer, s Snapshot) error {
+	p := &promWriter{host: s.Host, labels: s.Labels}
+
+	p.gauge("gostats_uptime_seconds", "Seconds since boot.", float64(s.UptimeSec))
+	if c := s.Cloud; c != nil {
+		p.family("gostats_cloud_info", "gauge", "Cloud instance metadata; always 1.", promSample{
+			labels: []string{"provider", c.Provider, "instance_id", c.InstanceID, "region", c.Region, "instance_type", c.InstanceType},
+			value:  1,
+		})
+	}
+
+	p.optGauge("gostats_cpu_percent", "CPU utilization across all cores.", s.CPUPercent)
+	p.optGauge("gostats_cpu_max_percent", "Highest CPU utilization among the sample's sub-samples.", s.CPUMaxPercent)
+	cores := make([]promSample, len(s.CPUPerCore))
+	for i, v := range s.CPUPerCore {
+		cores[i] = promSample{labels: []string{"core", strconv.Itoa(i)}, value: v}
+	}
+	p.family("gostats_cpu_core_percent", "gauge", "CPU utilization per core.", cores...)
+	if ci := s.CPUInfo; ci != nil {
+		var models []promSample
+		if len(ci.Sockets) > 0 {
+			for _, so := range ci.Sockets {
+				models = append(models, promSample{labels: []string{"socket", so.ID, "model", so.Model}, value: 1})
+			}
+		} else if ci.Model != "" {
+			models = append(models, promSample{labels: []string{"model", ci.Model}, value: 1})
+		}
+		p.family("gostats_cpu_info", "gauge", "CPU model, per socket where they differ; always 1.", models...)
+		p.optGauge("gostats_cpu_frequency_hertz", "Current CPU clock averaged over logical CPUs.", scaledFloat(ci.CurMHz, 1e6))
+		p.optGauge("gostats_cpu_max_frequency_hertz", "Highest rated CPU clock.", scaledFloat(ci.MaxMHz, 1e6))
+	}
+
+	p.optGauge("gostats_load1", "1-minute load average.", s.Load1)
+	p.optGauge("gostats_load5", "5-minute load average.", s.Load5)
+	p.optGauge("gostats_load15", "15-minute load average.", s.Load15)
+	p.optGauge("gostats_load_per_core", "1-minute load average divided by logical CPU count.", s.LoadPerCore)
+	if s.LoadSynthetic {
+		p.gauge("gostats_load_synthetic", "Load averages are approximated from the Windows processor queue length (1).", 1)
+	}
+
+	p.optGauge("gostats_mem_used_bytes", "Used virtual memory in bytes.", scaled(s.MemUsedMB, 1024*1024))
+	p.optGauge("gostats_mem_total_bytes", "Total virtual memory in bytes.", scaled(s.MemTotalMB, 1024*1024))
+	p.optGauge("gostats_mem_used_percent", "Used virtual memory percent.", s.MemUsedPct)
+	p.optGauge("gostats_mem_available_bytes", "Memory available for new allocations, including reclaimable cache.", scaled(s.MemAvailableMB, 1024*1024))
+	p.optGauge("gostats_mem_cached_bytes", "Page cache memory in bytes.", scaled(s.MemCachedMB, 1024*1024))
+	p.optGauge("gostats_mem_buffers_bytes", "Buffer memory in bytes.", scaled(s.MemBuffersMB, 1024*1024))
+	p.optGauge("gostats_swap_used_bytes", "Used swap in bytes.", scaled(s.SwapUsedMB, 1024*1024))
+	p.optGauge("gostats_swap_total_bytes", "Total swap in bytes.", scaled(s.SwapTotalMB, 1024*1024))
+	p.optGauge("gostats_swap_used_percent", "Used swap percent.", s.SwapUsedPct)
+
+	var used, total, pct, inodes, inodesPct []promSample
+	for _, d := range s.Disks {
+		l := []string{"path", d.Path}
+		used = append(used, promSample{labels: l, value: d.UsedGB * (1024 * 1024 * 1024)})
+		total = append(total, promSample{labels: l, value: d.TotalGB * (1024 * 1024 * 1024)})
+		pct = append(pct, promSample{labels: l, value: d.UsedPct})
+		if d.InodesUsedPct != nil {
+			inodes = append(inodes, promSample{labels: l, value: float64(d.InodesUsed)})
+			inodesPct = append(inodesPct, promSample{labels: l, value: *d.InodesUsedPct})
+		}
+	}
+	p.family("gostats_disk_used_bytes", "gauge", "Used disk space in bytes.", used...)
+	p.family("gostats_disk_total_bytes", "gauge", "Total disk space in bytes.", total...)
+	p.family("gostats_disk_used_percent", "gauge", "Used disk space percent.", pct...)
+	p.family("gostats_disk_inodes_used", "gauge", "Used inodes.", inodes...)
+	p.family("gostats_disk_inodes_used_percent", "gauge", "Used inodes percent.", inodesPct...)
+
+	var rb, wb, rc, wc []promSample
+	for _, d := range s.DiskIO {
+		l := []string{"device", d.Name}
+		rb = append(rb, promSample{labels: l, value: float64(d.ReadBytes)})
+		wb = append(wb, promSample{labels: l, value: float64(d.WriteBytes)})
+		rc = append(rc, promSample{labels: l, value: float64(d.ReadCount)})
+		wc = append(wc, promSample{labels: l, value: float64(d.WriteCount)})
+	}
+	p.family("gostats_disk_read_bytes_total", "counter", "Bytes read from the device.", rb...)
+	p.family("gostats_disk_written_bytes_total", "counter", "Bytes written to the device.", wb...)
+	p.family("gostats_disk_reads_total", "counter", "Read operations completed on the device.", rc...)
+	p.family("gostats_disk_writes_total", "counter", "Write operations completed on the device.", wc...)
+
+	p.optCounter("gostats_context_switches_total", "Context switches since boot.", s.CtxSwitches)
+	p.optCounter("gostats_interrupts_total", "Interrupts serviced since boot.", s.Interrupts)
+	p.optGauge("gostats_fds_open", "Allocated file descriptors system-wide.", scaled(s.FDsOpen, 1))
+	p.optGauge("gostats_fds_max", "System-wide file descriptor limit.", scaled(s.FDsMax, 1))
+
+	if cg := s.Cgroup; cg != nil {
+		p.optGauge("gostats_cgroup_mem_used_bytes", "Memory charged to this cgroup in bytes.", ptr(float64(cg.MemUsedMB)*(1024*1024)))
+		p.optGauge("gostats_cgroup_mem_limit_bytes", "Memory limit of this cgroup in bytes.", scaled(cg.MemLimitMB, 1024*1024))
+		p.optGauge("gostats_cgroup_cpu_limit_cores", "CPU limit of this cgroup in cores.", cg.CPULimitCores)
+		p.optGauge("gostats_cgroup_cpu_percent", "CPU usage relative to the cgroup limit.", cg.CPUPercent)
+	}
+	if s.PSI != nil {
+		var avg10, avg60, avg300, total []promSample
+		for _, res := range s.PSI.resources() {
+			if res.r == nil {
+				continue
+			}
+			for _, ln := range res.r.lines() {
+				labels := []string{"resource", res.name, "kind", ln.kind}
+				avg10 = append(avg10, promSample{labels: labels, value: ln.l.Avg10})
+				avg60 = append(avg60, promSample{labels: labels, value: ln.l.Avg60})
+				avg300 = append(avg300, promSample{labels: labels, value: ln.l.Avg300})
+				total = append(total, promSample{labels: labels, value: float64(ln.l.TotalUs) / 1e6})
+			}
+		}
+		p.family("gostats_pressure_avg10_percent", "gauge", "Share of the last 10s that tasks stalled on the resource.", avg10...)
+		p.family("gostats_pressure_avg60_percent", "gauge", "Share of the last 60s that tasks stalled on the resource.", avg60...)
+		p.family("gostats_pressure_avg300_percent", "gauge", "Share of the last 300s that tasks stalled on the resource.", avg300...)
+		p.family("gostats_pressure_stalled_seconds_total", "counter", "Total time tasks stalled on the resource.", total...)
+	}
+	p.optGauge("gostats_processes", "Number of processes.", scaled(s.ProcCount, 1))
+	p.optGauge("gostats_threads", "Number of threads across all processes.", scaled(s.ThreadCount, 1))
+	p.optGauge("gostats_zombie_processes", "Number of zombie processes.", scaled(s.ZombieCount, 1))
+
+	var tc []promSample
+	for _, t := range s.Temperatures {
+		tc = append(tc, promSample{labels: []string{"sensor", t.Key}, value: t.Current})
+	}
+	p.family("gostats_temperature_celsius", "gauge", "Sensor temperature in degrees Celsius.", tc...)
+
+	var gu, gm, gt, gtemp []promSample
+	for _, g := range s.GPUs {
+		l := []string{"gpu", strconv.Itoa(g.Index), "name", g.Name}
+		if g.UtilPct != nil {
+			gu = append(gu, promSample{labels: l, value: *g.UtilPct})
+		}
+		if g.MemUsedMB != nil {
+			gm = append(gm, promSample{labels: l, value: float64(*g.MemUsedMB) * (1024 * 1024)})
+		}
+		if g.MemTotalMB != nil {
+			gt = append(gt, promSample{labels: l, value: float64(*g.MemTotalMB) * (1024 * 1024)})
+		}
+		if g.TempC != nil {
+			gtemp = append(gtemp, promSample{labels: l, value: *g.TempC})
+		}
+	}
+	p.family("gostats_gpu_utilization_percent", "gauge", "GPU utilization percent.", gu...)
+	p.family("gostats_gpu_mem_used_bytes", "gauge", "GPU memory used in bytes.", gm...)
+	p.family("gostats_gpu_mem_total_bytes", "gauge", "GPU memory total in bytes.", gt...)
+	p.family("gostats_gpu_temperature_celsius", "gauge", "GPU temperature in degrees Celsius.", gtemp...)
+
+	p.optGauge("gostats_battery_percent", "Battery charge in percent.", s.BatteryPercent)
+	if s.BatteryCharging != nil {
+		charging := 0.0
+		if *s.BatteryCharging {
+			charging = 1
+		}
+		p.gauge("gostats_battery_charging", "Whether the battery is charging (1) or not (0).", charging)
+	}
+
+	var custom []promSample
+	for _, k := range slices.Sorted(maps.Keys(s.Custom)) {
+		custom = append(custom, promSample{labels: []string{"name", k}, value: s.Custom[k]})
+	}
+	p.family("gostats_custom", "gauge", "Values reported by --exec commands.", custom...)
+
+	p.optCounter("gostats_net_received_bytes_total", "Bytes received on all interfaces.", s.NetBytesIn)
+	p.optCounter("gostats_net_sent_bytes_total", "Bytes sent on all interfaces.", s.NetBytesOut)
+	p.optCounter("gostats_net_receive_errors_total", "Receive errors on all interfaces.", s.NetErrIn)
+	p.optCounter("gostats_net_transmit_errors_total", "Transmit errors on all interfaces.", s.NetErrOut)
+	p.optCounter("gostats_net_receive_drops_total", "Inbound packets dropped on all interfaces.", s.NetDropIn)
+	p.optCounter("gostats_net_transmit_drops_total", "Outbound packets dropped on all interfaces.", s.NetDropOut)
+	var ib, ob []promSample
+	for _, n := range s.NetInterfaces {
+		l := []string{"interface", n.Name}
+		ib = append(ib, promSample{labels: l, value: float64(n.BytesIn)})
+		ob = append(ob, promSample{labels: l, value: float64(n.BytesOut)})
+	}
+	p.family("gostats_net_interface_received_bytes_total", "counter", "Bytes received per interface.", ib...)
+	p.family("gostats_net_interface_sent_bytes_total", "counter", "Bytes sent per interface.", ob...)
+	var states []promSample
+	for _, st := range slices.Sorted(maps.Keys(s.TCPStates)) {
+		states = append(states, promSample{labels: []string{"state", st}, value: float64(s.TCPStates[st])})
+	}
+	p.family("gostats_tcp_connections", "gauge", "TCP connections by state.", states...)
+	p.optGauge("gostats_net_receive_rate_bytes", "Bytes received per second since the previous sample.", s.NetRateInBps)
+	p.optGauge("gostats_net_send_rate_bytes", "Bytes sent per second since the previous sample.", s.NetRateOutBps)
+
+	_, err := io.WriteString(w, p.b.String())
+	return err
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var psi bool
+
+// PSI is Linux pressure stall information: the share of time some (or all,
+// for full) runnable tasks were stalled waiting on a resource, averaged over
+// the last 10, 60 and 300 seconds. It shows contention before utilization
+// alone does. A resource the kernel doesn't report is left out.
+type PSI struct {
+	CPU    *PSIResource `json:"cpu,omitempty"`
+	Memory *PSIResource `json:"memory,omitempty"`
+	IO     *PSIResource `json:"io,omitempty"`
+}
+
+// PSIResource holds the two lines of one /proc/pressure file. Kernels
+// before 5.13 have no full line for cpu.
+type PSIResource struct {
+	Some PSILine  `json:"some"`
+	Full *PSILine `json:"full,omitempty"`
+}
+
+// PSILine is one some/full line; the averages are percentages and Total is
+// the cumulative stall time.
+type PSILine struct {
+	Avg10   float64 `json:"avg10"`
+	Avg60   float64 `json:"avg60"`
+	Avg300  float64 `json:"avg300"`
+	TotalUs uint64  `json:"total_us" unit:"microseconds"`
+}
+
+// parsePSI reads the format of a /proc/pressure file:
+//
+//	some avg10=0.12 avg60=0.05 avg300=0.01 total=123456
+//	full avg10=0.00 avg60=0.00 avg300=0.00 total=789
+func parsePSI(text string) (*PSIResource, error) {
+	var r PSIResource
+	seenSome := false
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		kind, rest, _ := strings.Cut(line, " ")
+		var l PSILine
+		for _, kv := range strings.Fields(rest) {
+			k, v, ok := strings.Cut(kv, "=")
+			if !ok {
+				return nil, fmt.Errorf("malformed pressure line %q", line)
+			}
+			var err error
+			switch k {
+			case "avg10":
+				l.Avg10, err = strconv.ParseFloat(v, 64)
+			case "avg60":
+				l.Avg60, err = strconv.ParseFloat(v, 64)
+			case "avg300":
+				l.Avg300, err = strconv.ParseFloat(v, 64)
+			case "total":
+				l.TotalUs, err = strconv.ParseUint(v, 10, 64)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("malformed pressure line %q: %w", line, err)
+			}
+		}
+		switch kind {
+		case "some":
+			r.Some, seenSome = l, true
+		case "full":
+			r.Full = &l
+		}
+	}
+	if !seenSome {
+		return nil, fmt.Errorf("no \"some\" line in pressure file")
+	}
+	return &r, nil
+}
+
+// humanPSI renders the PSI column as the some avg10 of cpu, memory and io,
+// e.g. "4.9/0.0/0.2".
+func (s Snapshot) humanPSI() string {
+	if s.PSI == nil {
+		return "-/-/-"
+	}
+	cell := func(r *PSIResource) string {
+		if r == nil {
+			return "-"
+		}
+		return fmtFloat(r.Some.Avg10, 1)
+	}
+	return cell(s.PSI.CPU) + "/" + cell(s.PSI.Memory) + "/" + cell(s.PSI.IO)
+}
+
+type namedPSI struct {
+	name string
+	r    *PSIResource
+}
+
+// resources pairs each resource's name with its reading, in file order.
+func (p *PSI) resources() []namedPSI {
+	return []namedPSI{{"cpu", p.CPU}, {"memory", p.Memory}, {"io", p.IO}}
+}
+
+type psiLine struct {
+	kind string
+	l    *PSILine
+}
+
+// lines returns the some line and, if present, the full line.
+func (r *PSIResource) lines() []psiLine {
+	out := []psiLine{{"some", &r.Some}}
+	if r.Full != nil {
+		out = append(out, psiLine{"full", r.Full})
+	}
+	return out
+}
+
+func init() {
+	collectCmd.Flags().BoolVar(&psi, "psi", false, "report pressure stall information (some/full avg10/avg60/avg300) for cpu, memory and io (Linux 4.20+; omitted without it)")
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"syscall"
+)
+
+// readPSI parses /proc/pressure/{cpu,memory,io}. Kernels without PSI lack
+// the directory and kernels booted with psi=0 refuse the reads; either way
+// there is nothing to report and readPSI returns errUnsupported.
+func readPSI() (*PSI, error) {
+	var p PSI
+	found := false
+	for _, f := range []struct {
+		name string
+		dst  **PSIResource
+	}{{"cpu", &p.CPU}, {"memory", &p.Memory}, {"io", &p.IO}} {
+		data, err := os.ReadFile("/proc/pressure/" + f.name)
+		if errors.Is(err, fs.ErrNotExist) || errors.Is(err, syscall.EOPNOTSUPP) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		r, err := parsePSI(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.name, err)
+		}
+		*f.dst, found = r, true
+	}
+	if !found {
+		return nil, errUnsupported
+	}
+	return &p, nil
+}
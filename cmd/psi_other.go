@@ -0,0 +1,8 @@
+//go:build !linux
+
+package cmd
+
+// readPSI reports nothing: pressure stall information is Linux-only.
+func readPSI() (*PSI, error) {
+	return nil, errUnsupported
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParsePSIMemory(t *testing.T) {
+	// /proc/pressure/memory on a host under memory pressure
+	r, err := parsePSI("some avg10=12.50 avg60=8.31 avg300=3.07 total=918273645\n" +
+		"full avg10=4.20 avg60=2.75 avg300=0.98 total=301288117\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (PSILine{Avg10: 12.5, Avg60: 8.31, Avg300: 3.07, TotalUs: 918273645}); r.Some != want {
+		t.Errorf("some = %+v, want %+v", r.Some, want)
+	}
+	if want := (PSILine{Avg10: 4.2, Avg60: 2.75, Avg300: 0.98, TotalUs: 301288117}); r.Full == nil || *r.Full != want {
+		t.Errorf("full = %+v, want %+v", r.Full, want)
+	}
+}
+
+func TestParsePSIOldKernelCPU(t *testing.T) {
+	// Before 5.13, /proc/pressure/cpu has no full line
+	r, err := parsePSI("some avg10=1.92 avg60=2.30 avg300=2.38 total=245342654\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Full != nil {
+		t.Errorf("full = %+v, want nil", r.Full)
+	}
+	if r.Some.TotalUs != 245342654 {
+		t.Errorf("some total = %d, want 245342654", r.Some.TotalUs)
+	}
+}
+
+func TestParsePSIMalformed(t *testing.T) {
+	for _, text := range []string{
+		"",
+		"full avg10=0.00 avg60=0.00 avg300=0.00 total=0",     // no some line
+		"some avg10=0.00 avg60 avg300=0.00 total=0",          // key without a value
+		"some avg10=0,12 avg60=0.00 avg300=0.00 total=0",     // not a float
+		"some avg10=0.00 avg60=0.00 avg300=0.00 total=-1",    // negative total
+		"some avg10=0.00 avg60=0.00 avg300=0.00 total=1.5e3", // total is an integer
+	} {
+		if r, err := parsePSI(text); err == nil {
+			t.Errorf("parsePSI(%q) = %+v, want an error", text, r)
+		}
+	}
+	// Unknown keys from a future kernel are ignored, not rejected
+	if _, err := parsePSI("some avg10=0.00 avg60=0.00 avg300=0.00 avg900=0.00 total=7"); err != nil {
+		t.Errorf("unknown key rejected: %v", err)
+	}
+}
+
+func TestReadPSIThisHost(t *testing.T) {
+	p, err := readPSI()
+	if errors.Is(err, errUnsupported) {
+		t.Skip("no pressure stall information on this host")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, r := range map[string]*PSIResource{"cpu": p.CPU, "memory": p.Memory, "io": p.IO} {
+		if r == nil {
+			continue
+		}
+		for _, avg := range []float64{r.Some.Avg10, r.Some.Avg60, r.Some.Avg300} {
+			if avg < 0 || avg > 100 {
+				t.Errorf("%s some average %v is not a percentage", name, avg)
+			}
+		}
+		// All tasks stalled implies some were, so full can't outgrow some
+		if r.Full != nil && r.Full.TotalUs > r.Some.TotalUs {
+			t.Errorf("%s full total %d exceeds some total %d", name, r.Full.TotalUs, r.Some.TotalUs)
+		}
+	}
+}
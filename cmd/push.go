@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	pushURL     string
+	pushBatch   int
+	pushHeaders []string
+)
+
+// Sinks are written from the sampling loop, so a failing collector holds
+// it up. pushDeadline bounds that: each flush, all tries and the backoff
+// between them included, gives up after it. Up to pushAttempts tries are
+// made, each limited to pushTimeout, waiting pushBackoff and then twice
+// that between them. Two tries that hang for their full pushTimeout use up
+// 4.5s of the 5s, so the third only runs after one of them failed fast,
+// as a refused connection or a 503 does.
+const (
+	pushAttempts = 3
+	pushBackoff  = 500 * time.Millisecond
+	pushTimeout  = 2 * time.Second
+	pushDeadline = 5 * time.Second
+)
+
+// pushSink POSTs snapshots as JSON to a collector. With --push-batch 1 each
+// request is one object; larger batches are sent as a JSON array. A batch
+// that still fails after retries is dropped.
+type pushSink struct {
+	client  *http.Client
+	headers http.Header
+	pending []Snapshot
+}
+
+func openPush(ctx context.Context) (sink, error) {
+	if pushURL == "" {
+		return nil, nil
+	}
+	if pushBatch < 1 {
+		return nil, fmt.Errorf("--push-batch must be at least 1, got %d", pushBatch)
+	}
+	h := http.Header{}
+	for _, kv := range pushHeaders {
+		k, v, ok := strings.Cut(kv, ":")
+		if !ok || strings.TrimSpace(k) == "" {
+			return nil, fmt.Errorf("invalid --push-header %q: want \"Name: value\"", kv)
+		}
+		h.Add(strings.TrimSpace(k), strings.TrimSpace(v))
+	}
+	return &pushSink{client: &http.Client{Timeout: pushTimeout}, headers: h}, nil
+}
+
+func (p *pushSink) name() string { return "push" }
+
+func (p *pushSink) write(ctx context.Context, snap Snapshot) error {
+	p.pending = append(p.pending, snap)
+	if len(p.pending) < pushBatch {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, pushDeadline)
+	defer cancel()
+	return p.flush(ctx)
+}
+
+// flush sends the pending batch, retrying until ctx ends; write and close
+// bound it by pushDeadline.
+func (p *pushSink) flush(ctx context.Context) error {
+	if len(p.pending) == 0 {
+		return nil
+	}
+	var v any = p.pending
+	if pushBatch == 1 {
+		v = p.pending[0]
+	}
+	body, err := json.Marshal(v)
+	p.pending = nil
+	if err != nil {
+		return err
+	}
+
+	backoff := pushBackoff
+	for attempt := 1; ; attempt++ {
+		retry, err := postJSON(ctx, p.client, pushURL, p.headers, body)
+		if err == nil || !retry || attempt == pushAttempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// postJSON sends one JSON POST, reporting whether a failure is worth
+// retrying: network errors, 429 and 5xx are; other client errors won't
+// change.
+func postJSON(ctx context.Context, client *http.Client, url string, headers http.Header, body []byte) (retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	if headers != nil {
+		req.Header = headers.Clone()
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return retry, fmt.Errorf("POST %s: %s", url, resp.Status)
+	}
+	return false, nil
+}
+
+// close sends any partial batch. The run's context is usually cancelled by
+// now (ctrl-c), so the final flush gets a fresh pushDeadline of its own.
+func (p *pushSink) close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), pushDeadline)
+	defer cancel()
+	return p.flush(ctx)
+}
+
+func init() {
+	registerSink(openPush)
+	collectCmd.Flags().StringVar(&pushURL, "push-url", "", "POST each snapshot as JSON to this collector URL")
+	collectCmd.Flags().IntVar(&pushBatch, "push-batch", 1, "send --push-url snapshots in batches of N (as a JSON array when N > 1)")
+	collectCmd.Flags().StringArrayVar(&pushHeaders, "push-header", nil, "extra HTTP header for --push-url, as \"Name: value\" (repeatable)")
+}
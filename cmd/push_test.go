@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// pushTo points --push-url at a test server running handler and opens the
+// sink with a batch size of batch.
+func pushTo(t *testing.T, batch int, handler http.HandlerFunc) *pushSink {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	oldURL, oldBatch := pushURL, pushBatch
+	t.Cleanup(func() { pushURL, pushBatch = oldURL, oldBatch })
+	pushURL, pushBatch = srv.URL, batch
+	s, err := openPush(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s.(*pushSink)
+}
+
+func TestPushRetriesUnavailable(t *testing.T) {
+	var calls atomic.Int32
+	p := pushTo(t, 1, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.Error(w, "overloaded", http.StatusServiceUnavailable)
+	})
+	if err := p.write(context.Background(), Snapshot{Host: "web-1"}); err == nil {
+		t.Error("write succeeded against a 503")
+	}
+	if n := calls.Load(); n != pushAttempts {
+		t.Errorf("%d tries, want %d", n, pushAttempts)
+	}
+}
+
+func TestPushDoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	p := pushTo(t, 1, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.Error(w, "bad token", http.StatusUnauthorized)
+	})
+	if err := p.write(context.Background(), Snapshot{}); err == nil {
+		t.Error("write succeeded against a 401")
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("%d tries of a 401, want 1", n)
+	}
+}
+
+func TestPushDeadline(t *testing.T) {
+	if testing.Short() {
+		t.Skip("waits out the push deadline")
+	}
+	var calls atomic.Int32
+	hang := make(chan struct{})
+	p := pushTo(t, 1, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-hang // a collector that accepts and never answers
+	})
+	t.Cleanup(func() { close(hang) }) // before the server's Close waits on it
+	start := time.Now()
+	p.write(context.Background(), Snapshot{})
+	took := time.Since(start)
+	if took > pushDeadline+time.Second {
+		t.Errorf("write held the sampling loop for %s, want at most %s", took, pushDeadline)
+	}
+	// Each hung try is cut off at pushTimeout, which leaves room for a second
+	if n := calls.Load(); n != 2 {
+		t.Errorf("%d tries, want 2", n)
+	}
+}
+
+func TestPushCloseSendsPartialBatch(t *testing.T) {
+	got := make(chan []Snapshot, 1)
+	p := pushTo(t, 10, func(w http.ResponseWriter, r *http.Request) {
+		var batch []Snapshot
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			t.Errorf("batch is not a JSON array: %v", err)
+		}
+		got <- batch
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	for _, host := range []string{"web-1", "web-2", "web-3"} {
+		if err := p.write(ctx, Snapshot{Host: host}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cancel() // as on ctrl-c
+	if err := p.close(); err != nil {
+		t.Fatal(err)
+	}
+	if batch := <-got; len(batch) != 3 || batch[2].Host != "web-3" {
+		t.Errorf("final batch = %+v, want the three pending snapshots", batch)
+	}
+}
@@ -0,0 +1,68 @@
+package cmd
+
+// applyRates fills the per-second rate fields on s from the cumulative
+// counters in prev. The first sample of a stream has no prior, so the
+// rate fields are left nil.
+func (s *Snapshot) applyRates(prev *Snapshot) {
+	if prev == nil {
+		return
+	}
+	elapsed := s.Timestamp.Sub(prev.Timestamp).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	// CPU mode breakdown over the whole interval rather than the 200ms window
+	if prev.cpuTimesRaw != nil && s.cpuTimesRaw != nil {
+		if ct := cpuTimesPct(*prev.cpuTimesRaw, *s.cpuTimesRaw); ct != nil {
+			s.CPUTimes = ct
+		}
+	}
+
+	if s.Cgroup != nil {
+		s.Cgroup.applyCgroupRate(prev.Cgroup, elapsed)
+	}
+
+	s.NetRateInBps = optCounterRate(prev.NetBytesIn, s.NetBytesIn, elapsed)
+	s.NetRateOutBps = optCounterRate(prev.NetBytesOut, s.NetBytesOut, elapsed)
+	s.NetErrInRate = optCounterRate(prev.NetErrIn, s.NetErrIn, elapsed)
+	s.NetErrOutRate = optCounterRate(prev.NetErrOut, s.NetErrOut, elapsed)
+	s.NetDropInRate = optCounterRate(prev.NetDropIn, s.NetDropIn, elapsed)
+	s.NetDropOutRate = optCounterRate(prev.NetDropOut, s.NetDropOut, elapsed)
+
+	s.CtxSwitchRate = optCounterRate(prev.CtxSwitches, s.CtxSwitches, elapsed)
+	s.InterruptRate = optCounterRate(prev.Interrupts, s.Interrupts, elapsed)
+
+	for i := range s.DiskIO {
+		cur := &s.DiskIO[i]
+		for _, p := range prev.DiskIO {
+			if p.Name != cur.Name {
+				continue
+			}
+			cur.ReadBps = counterRate(p.ReadBytes, cur.ReadBytes, elapsed)
+			cur.WriteBps = counterRate(p.WriteBytes, cur.WriteBytes, elapsed)
+			cur.ReadOpsS = counterRate(p.ReadCount, cur.ReadCount, elapsed)
+			cur.WriteOpsS = counterRate(p.WriteCount, cur.WriteCount, elapsed)
+			break
+		}
+	}
+}
+
+// optCounterRate is counterRate for counters that may not have been
+// collected on either side.
+func optCounterRate(prev, cur *uint64, elapsed float64) *float64 {
+	if prev == nil || cur == nil {
+		return nil
+	}
+	return counterRate(*prev, *cur, elapsed)
+}
+
+// counterRate returns the per-second rate between two readings of a
+// monotonic counter. A counter that went backwards (wrap or reset) yields
+// nil rather than a bogus negative rate.
+func counterRate(prev, cur uint64, elapsed float64) *float64 {
+	if cur < prev {
+		return nil
+	}
+	r := float64(cur-prev) / elapsed
+	return &r
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"sync"
+)
+
+var redact bool
+
+// redactKey is drawn once per run. Hashing host names with it keeps them
+// consistent across a run's samples, so they can still be told apart and
+// correlated, while a captured name can't be confirmed by hashing guesses,
+// nor matched against another run's output.
+var redactKey = sync.OnceValue(func() []byte {
+	return []byte(rand.Text())
+})
+
+// redactHost replaces a host name with a stable pseudonym like
+// "host-3f2a9c01b7de".
+func redactHost(name string) string {
+	return pseudonym("host-", name)
+}
+
+// pseudonym hashes v under the run's key, keeping "" empty.
+func pseudonym(prefix, v string) string {
+	if v == "" {
+		return ""
+	}
+	m := hmac.New(sha256.New, redactKey())
+	m.Write([]byte(v))
+	return prefix + hex.EncodeToString(m.Sum(nil)[:6])
+}
+
+// applyRedact strips what identifies the host on a network from s, for
+// --redact: the host name and cloud instance ID are pseudonymized and
+// addresses are dropped. The cloud provider, region, zone and instance type
+// are kept; they are shared by many machines and useful in a bug report.
+func (s *Snapshot) applyRedact() {
+	if !redact {
+		return
+	}
+	s.Host = redactHost(s.Host)
+	if s.Cloud != nil {
+		c := *s.Cloud // shared by every sample of the run
+		c.InstanceID = pseudonym("instance-", c.InstanceID)
+		s.Cloud = &c
+	}
+	for i := range s.Users {
+		s.Users[i].Host = "" // the remote end of a login is an address or name
+	}
+	if s.Ports != nil {
+		// Sockets on the same port at different addresses collapse into one
+		var ports []ListenSocket
+		for _, l := range s.Ports.Listening {
+			if l = (ListenSocket{Port: l.Port}); !slices.Contains(ports, l) {
+				ports = append(ports, l)
+			}
+		}
+		s.Ports.Listening = ports
+	}
+}
+
+func init() {
+	collectCmd.Flags().BoolVar(&redact, "redact", false, "replace the host name and cloud instance ID with hashes that are stable within the run and leave out IP addresses and login hosts, for sharing output publicly (cloud region, zone and instance type are kept)")
+}
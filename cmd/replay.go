@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var replaySpeed string
+
+// replayOutputFlags are the collect flags that shape output rather than
+// collection. replay shares them, so a capture can be re-rendered any way
+// collect could have written it in the first place.
+var replayOutputFlags = []string{
+	"json", "format", "pretty", "compact", "fields", "precision", "no-header", "repeat-header",
+	"color", "timestamp-format", "utc", "timezone", "measurement", "graphite-prefix",
+	"output", "output-format", "gzip", "rotate-size", "rotate-count", "summary", "redact",
+}
+
+// shareReplayFlags adds replayOutputFlags to replay. It runs from Execute
+// rather than init, once every file's init has registered its flags.
+func shareReplayFlags() {
+	for _, name := range replayOutputFlags {
+		replayCmd.Flags().AddFlag(collectCmd.Flags().Lookup(name))
+	}
+}
+
+// parseSpeed reads --speed: a factor such as 2x or 0.5 applied to the
+// recorded cadence, or max for no delay at all, which it reports as 0.
+func parseSpeed(s string) (float64, error) {
+	if s == "max" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(strings.TrimSuffix(s, "x"), 64)
+	if err != nil || f <= 0 {
+		return 0, fmt.Errorf("invalid --speed %q (want e.g. 1x, 2x, 0.5x or max)", s)
+	}
+	return f, nil
+}
+
+// openCapture opens a file written by collect --json, or stdin for "-",
+// decompressing it if it is gzipped.
+func openCapture(path string) (io.ReadCloser, error) {
+	f := os.Stdin
+	if path != "-" {
+		var err error
+		if f, err = os.Open(path); err != nil {
+			return nil, err
+		}
+	}
+	br := bufio.NewReader(f)
+	if magic, _ := br.Peek(2); len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return struct {
+			io.Reader
+			io.Closer
+		}{zr, f}, nil
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{br, f}, nil
+}
+
+// sleepCtx waits for d, reporting false if ctx ended first.
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
+var replayCmd = &cobra.Command{
+	Use:   "replay FILE",
+	Short: "Re-emit the snapshots of a saved collect --json capture",
+	Long: `replay reads the snapshots in FILE, as written by collect --json or
+--output (gzipped or not; - for stdin), and emits them again through the same
+output flags as collect: --format, --output, --fields, --summary and so on.
+Snapshots are spaced out by the gaps between their timestamps, divided by
+--speed, or sent back to back with --speed max. Only output is re-rendered,
+so the human table shows its standard columns.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+
+		speed, err := parseSpeed(replaySpeed)
+		if err != nil {
+			return err
+		}
+		outFmt, err := resolveFormat()
+		if err != nil {
+			return err
+		}
+		if err := resolveTimezone(); err != nil {
+			return err
+		}
+		if err := resolveColor(); err != nil {
+			return err
+		}
+		fileFmt, err := resolveOutputFormat(outFmt)
+		if err != nil {
+			return err
+		}
+		if err := resolveFields(outFmt, fileFmt); err != nil {
+			return err
+		}
+
+		in, err := openCapture(args[0])
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		w, err := openOutput(fileFmt)
+		if err != nil {
+			return err
+		}
+		defer w.Close()
+
+		out := newEmitters(w, outFmt, fileFmt, true)
+		if err := out.begin(); err != nil {
+			return err
+		}
+		var sum *summary
+		if summaryOut {
+			sum = newSummary()
+		}
+		dec := json.NewDecoder(in)
+		var last time.Time
+		for n := 1; ; n++ {
+			var snap Snapshot
+			if err := dec.Decode(&snap); errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				return fmt.Errorf("%s: snapshot %d: %w", args[0], n, err)
+			}
+			if speed > 0 && !last.IsZero() {
+				if gap := snap.Timestamp.Sub(last); gap > 0 && !sleepCtx(ctx, time.Duration(float64(gap)/speed)) {
+					break
+				}
+			}
+			last = snap.Timestamp
+			if tsLocation != nil {
+				snap.Timestamp = snap.Timestamp.In(tsLocation)
+			}
+			snap.applyRedact()
+			if err := out.emit(snap); err != nil {
+				return err
+			}
+			sum.add(snap)
+			if ctx.Err() != nil {
+				break
+			}
+		}
+		return sum.print(os.Stderr)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(replayCmd)
+	replayCmd.Flags().StringVar(&replaySpeed, "speed", "1x", "replay at this multiple of the recorded cadence (e.g. 2x, 0.5x), or max for as fast as possible")
+}
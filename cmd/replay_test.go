@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseSpeed(t *testing.T) {
+	for in, want := range map[string]float64{"1x": 1, "2x": 2, "0.5x": 0.5, "10": 10, "max": 0} {
+		if got, err := parseSpeed(in); err != nil || got != want {
+			t.Errorf("parseSpeed(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	// 0 would mean max, so only "max" may say so
+	for _, in := range []string{"0x", "0", "-2x", "fast", "MAX", "2xx", ""} {
+		if _, err := parseSpeed(in); err == nil {
+			t.Errorf("parseSpeed(%q) succeeded, want an error", in)
+		}
+	}
+}
+
+// writeCapture saves snaps the way collect --json --compact --output does,
+// with or without --gzip.
+func writeCapture(t *testing.T, path string, gz bool, snaps ...Snapshot) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var w io.Writer = f
+	if gz {
+		zw := gzip.NewWriter(f)
+		defer zw.Close()
+		w = zw
+	}
+	enc := json.NewEncoder(w)
+	for _, s := range snaps {
+		if err := enc.Encode(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestOpenCapture(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	snaps := []Snapshot{
+		{Timestamp: start, Host: "web-1", CPUPercent: ptr(12.5)},
+		{Timestamp: start.Add(5 * time.Second), Host: "web-1", CPUPercent: ptr(80.0)},
+	}
+	dir := t.TempDir()
+	// Gzip is recognized by its magic bytes, whatever the file is called
+	for name, gz := range map[string]bool{"plain.json": false, "capture.json.gz": true, "renamed.json": true} {
+		path := filepath.Join(dir, name)
+		writeCapture(t, path, gz, snaps...)
+		r, err := openCapture(path)
+		if err != nil {
+			t.Fatalf("openCapture(%s): %v", name, err)
+		}
+		dec := json.NewDecoder(r)
+		for i, want := range snaps {
+			var got Snapshot
+			if err := dec.Decode(&got); err != nil {
+				t.Fatalf("%s: snapshot %d: %v", name, i+1, err)
+			}
+			if !got.Timestamp.Equal(want.Timestamp) || got.Host != want.Host || *got.CPUPercent != *want.CPUPercent {
+				t.Errorf("%s: snapshot %d = %+v, want %+v", name, i+1, got, want)
+			}
+		}
+		if err := dec.Decode(new(Snapshot)); !errors.Is(err, io.EOF) {
+			t.Errorf("%s: after the last snapshot got %v, want EOF", name, err)
+		}
+		r.Close()
+	}
+}
+
+func TestOpenCaptureErrors(t *testing.T) {
+	if _, err := openCapture(filepath.Join(t.TempDir(), "missing.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("missing file: got %v, want ErrNotExist", err)
+	}
+	// The gzip magic followed by garbage is a corrupt archive, not JSON
+	path := filepath.Join(t.TempDir(), "bad.gz")
+	if err := os.WriteFile(path, []byte{0x1f, 0x8b, 'n', 'o', 'p', 'e'}, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := openCapture(path); err == nil {
+		t.Error("corrupt gzip opened without an error")
+	}
+}
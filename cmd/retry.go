@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+var (
+	retries    int
+	retryDelay time.Duration
+)
+
+// runCollector runs c, retrying up to --retries more times with a doubling
+// delay while it fails, so a transient error doesn't cost a whole sample of
+// that metric. The waits end early when ctx does, and a collector that can
+// never work on this platform isn't retried. The last attempt's result,
+// partial or not, is what's returned.
+func runCollector(ctx context.Context, c collector) (func(*Snapshot), error) {
+	apply, err := c.run(ctx)
+	delay := retryDelay
+	for attempt := 1; attempt <= retries && err != nil && !errors.Is(err, errUnsupported); attempt++ {
+		slog.Debug("retrying collector", "collector", c.name, "attempt", attempt, "delay", delay, "err", err)
+		select {
+		case <-ctx.Done():
+			return apply, err
+		case <-time.After(delay):
+		}
+		apply, err = c.run(ctx)
+		delay *= 2
+	}
+	return apply, err
+}
+
+func validateRetryFlags() error {
+	if retries < 0 {
+		return fmt.Errorf("--retries must not be negative, got %d", retries)
+	}
+	if retryDelay < 0 {
+		return fmt.Errorf("--retry-delay must not be negative, got %s", retryDelay)
+	}
+	return nil
+}
+
+func init() {
+	collectCmd.Flags().IntVar(&retries, "retries", 0, "retry a failing collector up to N more times within a sample")
+	collectCmd.Flags().DurationVar(&retryDelay, "retry-delay", 100*time.Millisecond, "wait before the first --retries attempt, doubling for each one after")
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"time"
+)
+
+var runStatsOut bool
+
+// runStatsLogEvery is how often a stream logs its run stats at debug level.
+const runStatsLogEvery = 10 * time.Second
+
+// runStats tracks whether a stream keeps up with its interval: how many
+// samples it took, the interval actually achieved between them, how long
+// collections take, and how many ticks went by unused because a sample was
+// still running. Missed ticks are counted from the ticks' nominal times, so
+// --jitter doesn't register as missing.
+type runStats struct {
+	every    time.Duration
+	lastTick time.Time
+	missed   int
+
+	samples     int
+	first, last time.Time // when the first and latest samples started
+	collectSum  time.Duration
+	collectMax  time.Duration
+	logged      time.Time
+}
+
+// newRunStats starts tracking ticks every apart, the first one due a whole
+// interval after start.
+func newRunStats(every time.Duration, start time.Time) *runStats {
+	return &runStats{every: every, lastTick: start, logged: time.Now()}
+}
+
+// tick records a tick's nominal time. The ticker drops ticks nobody was
+// waiting for, so a gap of several intervals means the ones between were
+// missed.
+func (r *runStats) tick(t time.Time) {
+	if gap := int(math.Round(float64(t.Sub(r.lastTick)) / float64(r.every))); gap > 1 {
+		r.missed += gap - 1
+	}
+	r.lastTick = t
+}
+
+// sampled records one collection that started at start and took took.
+func (r *runStats) sampled(start time.Time, took time.Duration) {
+	if r.samples == 0 {
+		r.first = start
+	}
+	r.last = start
+	r.samples++
+	r.collectSum += took
+	r.collectMax = max(r.collectMax, took)
+	if time.Since(r.logged) >= runStatsLogEvery {
+		r.logged = time.Now()
+		slog.Debug("run stats", "samples", r.samples, "interval", r.every, "achieved", r.achieved(), "missed_ticks", r.missed, "collect_max", r.collectMax.Round(time.Microsecond))
+	}
+}
+
+// achieved is the mean time between sample starts.
+func (r *runStats) achieved() time.Duration {
+	if r.samples < 2 {
+		return 0
+	}
+	return (r.last.Sub(r.first) / time.Duration(r.samples-1)).Round(time.Microsecond)
+}
+
+// print writes the --run-stats report.
+func (r *runStats) print(w io.Writer) error {
+	achieved := "-"
+	rate := "-"
+	if a := r.achieved(); a > 0 {
+		achieved = a.String()
+		rate = fmtFloat(float64(time.Second)/float64(a), 2)
+	}
+	mean := time.Duration(0)
+	if r.samples > 0 {
+		mean = (r.collectSum / time.Duration(r.samples)).Round(time.Microsecond)
+	}
+	_, err := io.WriteString(w, alignTable(
+		fmt.Sprintf("samples:\t%d", r.samples),
+		"interval:\t"+r.every.String(),
+		"achieved:\t"+achieved+" ("+rate+" samples/s)",
+		fmt.Sprintf("missed ticks:\t%d", r.missed),
+		"collect time:\t"+mean.String()+" mean, "+r.collectMax.Round(time.Microsecond).String()+" max",
+	))
+	return err
+}
+
+func init() {
+	collectCmd.Flags().BoolVar(&runStatsOut, "run-stats", false, "print samples taken, intended vs achieved interval and missed ticks to stderr when a stream ends")
+}
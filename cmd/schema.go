@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// schemaUnits maps JSON key suffixes to the unit they denote. Longer
+// suffixes come first so "_per_sec" wins over "_sec".
+var schemaUnits = []struct{ suffix, unit string }{
+	{"_per_sec", "per second"},
+	{"_bps", "bytes per second"},
+	{"_pct", "percent"},
+	{"_percent", "percent"},
+	{"_mb", "MiB"},
+	{"_gb", "GiB"},
+	{"_sec", "seconds"},
+	{"_bytes", "bytes"},
+}
+
+// schemaUnit returns the unit of a field: an explicit `unit` tag, else one
+// inferred from the JSON key suffix.
+func schemaUnit(f reflect.StructField, name string) string {
+	if u := f.Tag.Get("unit"); u != "" {
+		return u
+	}
+	for _, u := range schemaUnits {
+		if strings.HasSuffix(name, u.suffix) {
+			return u.unit
+		}
+	}
+	return ""
+}
+
+// jsonSchema describes t, deriving property names, optionality and units
+// from the struct fields and their json tags.
+func jsonSchema(t reflect.Type) map[string]any {
+	if t == reflect.TypeOf(time.Time{}) {
+		// Timestamps follow --timestamp-format, which may make them numbers
+		return map[string]any{
+			"oneOf": []any{
+				map[string]any{"type": "string"},
+				map[string]any{"type": "integer"},
+			},
+			"description": "RFC 3339 by default; epoch seconds or milliseconds with --timestamp-format epoch or epoch-ms, or any other --timestamp-format layout",
+		}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return jsonSchema(t.Elem())
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": jsonSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": jsonSchema(t.Elem())}
+	case reflect.Struct:
+		props := map[string]any{}
+		var required []string
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("json")
+			if !f.IsExported() || tag == "-" {
+				continue
+			}
+			name, opts, _ := strings.Cut(tag, ",")
+			if name == "" {
+				name = f.Name
+			}
+			p := jsonSchema(f.Type)
+			if u := schemaUnit(f, name); u != "" {
+				p["description"] = "unit: " + u
+			}
+			if f.Tag.Get("deprecated") == "true" {
+				p["deprecated"] = true
+			}
+			props[name] = p
+			// Pointers and omitempty fields are absent when not collected
+			if f.Type.Kind() != reflect.Pointer && !strings.Contains(opts, "omitempty") {
+				required = append(required, name)
+			}
+		}
+		s := map[string]any{"type": "object", "properties": props}
+		if len(required) > 0 {
+			s["required"] = required
+		}
+		return s
+	}
+	return map[string]any{}
+}
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print a JSON Schema for the collect --json output",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s := jsonSchema(reflect.TypeOf(Snapshot{}))
+		s["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+		s["title"] = "gostats snapshot"
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(s)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+}
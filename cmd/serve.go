@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var serveAddr string
+
+func metricsHandler(w http.ResponseWriter, r *http.Request) {
+	snap, err := collectOnce(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// Render first so a failure can still be answered with a 500 rather
+	// than a truncated 200
+	var buf bytes.Buffer
+	if err := writePrometheus(&buf, snap); err != nil {
+		slog.Error("rendering metrics failed", "err", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		slog.Warn("writing metrics response failed", "remote", r.RemoteAddr, "err", err)
+	}
+}
+
+func healthzHandler(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprintln(w, "ok")
+}
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve Prometheus metrics over HTTP",
+	Long: `serve answers /metrics in the Prometheus text format and /healthz with "ok".
+/ws upgrades to a WebSocket that streams JSON snapshots every ?interval=
+(default --ws-interval). Browser pages from another origin may only open /ws
+when that origin is listed in --ws-allow-origin.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if wsInterval <= 0 {
+			return fmt.Errorf("--ws-interval must be positive")
+		}
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /metrics", metricsHandler)
+		mux.HandleFunc("GET /healthz", healthzHandler)
+		h := newHub()
+		go h.runOnDemand(ctx, wsInterval)
+		mux.HandleFunc("GET /ws", wsHandler(h))
+		srv := &http.Server{Addr: serveAddr, Handler: mux}
+
+		errc := make(chan error, 1)
+		go func() { errc <- srv.ListenAndServe() }()
+
+		select {
+		case err := <-errc:
+			return err
+		case <-ctx.Done():
+		}
+
+		shutdownCtx, stop := context.WithTimeout(context.Background(), 5*time.Second)
+		defer stop()
+		if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().StringVar(&serveAddr, "addr", ":9100", "address to listen on")
+}
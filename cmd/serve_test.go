@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetricsHandler(t *testing.T) {
+	old := onlyGroups
+	t.Cleanup(func() {
+		onlyGroups = old
+		resolveGroups()
+	})
+	// Memory alone keeps the scrape quick: no CPU window to wait out
+	onlyGroups = []string{groupMem}
+	if err := resolveGroups(); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	metricsHandler(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", rec.Code, rec.Body)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{"# TYPE gostats_uptime_seconds gauge\n", "\ngostats_mem_used_percent{"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("no %q in the scrape:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "gostats_cpu_percent") {
+		t.Error("cpu metrics scraped with --only mem")
+	}
+}
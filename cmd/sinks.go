@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// sink receives every sample in addition to the primary output, e.g. a
+// network exporter. Write errors are reported but never end the run.
+type sink interface {
+	name() string
+	write(ctx context.Context, s Snapshot) error
+	close() error
+}
+
+// sinkOpeners are registered from init by each sink's file. An opener
+// returns a nil sink when its flags leave it disabled.
+var sinkOpeners []func(ctx context.Context) (sink, error)
+
+func registerSink(open func(ctx context.Context) (sink, error)) {
+	sinkOpeners = append(sinkOpeners, open)
+}
+
+type sinks []sink
+
+func openSinks(ctx context.Context) (sinks, error) {
+	var out sinks
+	for _, open := range sinkOpeners {
+		s, err := open(ctx)
+		if err != nil {
+			out.close()
+			return nil, err
+		}
+		if s != nil {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func (ss sinks) write(ctx context.Context, snap Snapshot) {
+	for _, s := range ss {
+		if err := s.write(ctx, snap); err != nil {
+			slog.Warn("sink write failed", "sink", s.name(), "err", err)
+		}
+	}
+}
+
+// close flushes and closes every sink, returning all errors.
+func (ss sinks) close() error {
+	var errs []error
+	for _, s := range ss {
+		if err := s.close(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
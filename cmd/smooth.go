@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+)
+
+var smoothN int
+
+// Smoothed holds simple moving averages over the last Window samples. Until
+// the window fills, Window is the number of samples averaged so far.
+type Smoothed struct {
+	Window        int      `json:"window"`
+	CPUPercent    *float64 `json:"cpu_percent,omitempty"`
+	MemUsedPct    *float64 `json:"mem_used_pct,omitempty"`
+	NetRateInBps  *float64 `json:"net_rate_in_bps,omitempty"`
+	NetRateOutBps *float64 `json:"net_rate_out_bps,omitempty"`
+}
+
+// smoother keeps a sliding window of recent readings for each smoothed
+// metric. A nil *smoother ignores everything, like a nil *summary.
+type smoother struct {
+	size                    int
+	n                       int
+	cpu, mem, netIn, netOut window
+}
+
+// window is a fixed-size ring of optional readings; missing readings (the
+// first net rate, a failed collection) take no slot.
+type window struct {
+	vals []float64
+	next int
+}
+
+// record adds v, if present, evicting the oldest reading once full.
+func (w *window) record(v *float64, size int) {
+	if v == nil {
+		return
+	}
+	if len(w.vals) < size {
+		w.vals = append(w.vals, *v)
+	} else {
+		w.vals[w.next] = *v
+		w.next = (w.next + 1) % size
+	}
+}
+
+// push records v and returns the mean of the window.
+func (w *window) push(v *float64, size int) *float64 {
+	w.record(v, size)
+	if len(w.vals) == 0 {
+		return nil
+	}
+	var sum float64
+	for _, x := range w.vals {
+		sum += x
+	}
+	return ptr(sum / float64(len(w.vals)))
+}
+
+func newSmoother(n int) *smoother {
+	if n <= 1 {
+		return nil
+	}
+	return &smoother{size: n}
+}
+
+// apply records s and attaches the current averages to it.
+func (m *smoother) apply(s *Snapshot) {
+	if m == nil {
+		return
+	}
+	m.n = min(m.n+1, m.size)
+	s.Smoothed = &Smoothed{
+		Window:        m.n,
+		CPUPercent:    m.cpu.push(s.CPUPercent, m.size),
+		MemUsedPct:    m.mem.push(s.MemUsedPct, m.size),
+		NetRateInBps:  m.netIn.push(s.NetRateInBps, m.size),
+		NetRateOutBps: m.netOut.push(s.NetRateOutBps, m.size),
+	}
+}
+
+// humanColumns renders the AVG_CPU% and AVG_MEM% cells.
+func (m *Smoothed) humanColumns() string {
+	if m == nil {
+		return "-\t-"
+	}
+	return optFloat(m.CPUPercent, 1) + "\t" + optFloat(m.MemUsedPct, 1)
+}
+
+func smoothHeader() string {
+	n := strconv.Itoa(smoothN)
+	return fmt.Sprintf("AVG%s_CPU%%\tAVG%s_MEM%%", n, n)
+}
+
+func init() {
+	collectCmd.Flags().IntVar(&smoothN, "smooth", 0, "also report moving averages of CPU%, mem% and net rates over the last N samples (streaming only)")
+}
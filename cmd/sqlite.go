@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+var (
+	sqlitePath  string
+	sqliteBatch int
+)
+
+// sqliteFlushEvery caps how long inserted rows can sit in an open
+// transaction at slow sample rates.
+const sqliteFlushEvery = 5 * time.Second
+
+// The headline metrics get their own columns for easy querying; data holds
+// the full snapshot JSON for json_extract on everything else.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS snapshots (
+	id               INTEGER PRIMARY KEY,
+	ts               INTEGER NOT NULL, -- unix milliseconds
+	host             TEXT NOT NULL,
+	cpu_percent      REAL,
+	load1            REAL,
+	mem_used_pct     REAL,
+	swap_used_pct    REAL,
+	net_rate_in_bps  REAL,
+	net_rate_out_bps REAL,
+	data             TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS snapshots_ts ON snapshots (ts);
+`
+
+// sqliteSink inserts one row per sample, committing every --sqlite-batch
+// rows or sqliteFlushEvery, whichever comes first.
+type sqliteSink struct {
+	db      *sql.DB
+	tx      *sql.Tx
+	insert  *sql.Stmt
+	rows    int
+	txStart time.Time
+}
+
+func openSQLite(ctx context.Context) (sink, error) {
+	if sqlitePath == "" {
+		return nil, nil
+	}
+	if sqliteBatch < 1 {
+		return nil, fmt.Errorf("--sqlite-batch must be at least 1, got %d", sqliteBatch)
+	}
+	db, err := sql.Open("sqlite", sqlitePath)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("sqlite: %s: %w", sqlitePath, err)
+	}
+	return &sqliteSink{db: db}, nil
+}
+
+func (s *sqliteSink) name() string { return "sqlite" }
+
+func (s *sqliteSink) write(ctx context.Context, snap Snapshot) error {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	if s.tx == nil {
+		// Not bound to ctx: cancelling it would roll back rows that close
+		// is about to commit.
+		if s.tx, err = s.db.Begin(); err != nil {
+			return err
+		}
+		s.insert, err = s.tx.Prepare(`INSERT INTO snapshots
+			(ts, host, cpu_percent, load1, mem_used_pct, swap_used_pct, net_rate_in_bps, net_rate_out_bps, data)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+		if err != nil {
+			s.tx.Rollback()
+			s.tx = nil
+			return err
+		}
+		s.txStart = time.Now()
+	}
+	if _, err := s.insert.Exec(snap.Timestamp.UnixMilli(), snap.Host,
+		snap.CPUPercent, snap.Load1, snap.MemUsedPct, snap.SwapUsedPct,
+		snap.NetRateInBps, snap.NetRateOutBps, string(data)); err != nil {
+		return err
+	}
+	s.rows++
+	if s.rows >= sqliteBatch || time.Since(s.txStart) >= sqliteFlushEvery {
+		return s.commit()
+	}
+	return nil
+}
+
+func (s *sqliteSink) commit() error {
+	if s.tx == nil {
+		return nil
+	}
+	err := s.tx.Commit()
+	s.tx, s.insert, s.rows = nil, nil, 0
+	return err
+}
+
+// close commits the pending batch before closing the database.
+func (s *sqliteSink) close() error {
+	return errors.Join(s.commit(), s.db.Close())
+}
+
+func init() {
+	registerSink(openSQLite)
+	collectCmd.Flags().StringVar(&sqlitePath, "sqlite", "", "also store every sample as a row in this SQLite database (table snapshots)")
+	collectCmd.Flags().IntVar(&sqliteBatch, "sqlite-batch", 100, "commit --sqlite inserts every N rows (and at least every 5s)")
+}
@@ -0,0 +1,291 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+var (
+	sshTarget     string
+	sshKeys       []string
+	sshKnownHosts string
+	sshTimeout    time.Duration
+	sshBin        string
+
+	// remote is set for the run when --ssh is given; collectOnce then
+	// samples through it instead of locally.
+	remote *remoteHost
+)
+
+// remoteFlags are the collect flags that shape what gets collected, so they
+// are passed on to the remote gostats. Output, sink and threshold flags
+// stay local and apply to the relayed snapshots.
+var remoteFlags = map[string]bool{
+	"disk-path": true, "only": true, "no-cpu": true, "no-load": true, "no-mem": true,
+	"no-disk": true, "no-net": true, "disk-io": true, "per-nic": true, "nic": true,
+	"per-cpu": true, "cpu-window": true, "cpu-times": true, "cpu-info": true, "all-disks": true,
+	"exclude-fs": true, "temps": true, "users": true, "procs": true, "cgroup": true,
+	"battery": true, "gpu": true, "top": true, "top-sort": true, "connections": true,
+	"ports": true, "fds": true, "kernel-stats": true, "psi": true, "synthetic-load": true, "subsamples": true, "subsample-max": true, "cloud": true, "units": true,
+	"collect-timeout": true, "verbose": true, "retries": true, "retry-delay": true,
+}
+
+// remoteHost runs gostats on another machine over one SSH connection and
+// relays its snapshots. Each sample is a `collect --json` on a fresh session
+// of that connection.
+type remoteHost struct {
+	client *ssh.Client
+	bin    string
+	args   string // forwarded collect flags, shell-quoted
+}
+
+// dialRemote connects to --ssh with key-based auth (ssh-agent and --ssh-key
+// files) and checks the host key against known_hosts. Unless --ssh-bin
+// names a gostats already installed there, this binary is copied over once
+// and cached under a name derived from its hash.
+func dialRemote(ctx context.Context, flags *pflag.FlagSet) (*remoteHost, error) {
+	userName, addr := parseSSHTarget(sshTarget)
+	auth, err := sshAuth()
+	if err != nil {
+		return nil, err
+	}
+	known := sshKnownHosts
+	if known == "" {
+		home, _ := os.UserHomeDir()
+		known = filepath.Join(home, ".ssh", "known_hosts")
+	}
+	hostKeys, err := knownhosts.New(known)
+	if err != nil {
+		return nil, fmt.Errorf("--ssh: reading known hosts: %w", err)
+	}
+	cfg := &ssh.ClientConfig{
+		User:            userName,
+		Auth:            auth,
+		HostKeyCallback: hostKeys,
+		Timeout:         sshTimeout,
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, sshTimeout)
+	defer cancel()
+	conn, err := (&net.Dialer{}).DialContext(dialCtx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("--ssh %s: %w", sshTarget, err)
+	}
+	conn.SetDeadline(time.Now().Add(sshTimeout)) // bounds the handshake
+	c, chans, reqs, err := ssh.NewClientConn(conn, addr, cfg)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("--ssh %s: %w", sshTarget, err)
+	}
+	conn.SetDeadline(time.Time{})
+
+	r := &remoteHost{client: ssh.NewClient(c, chans, reqs), bin: sshBin, args: forwardedFlags(flags)}
+	if r.bin == "" {
+		if err := r.install(ctx); err != nil {
+			r.close()
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// parseSSHTarget splits [user@]host[:port], defaulting to the local user
+// and port 22.
+func parseSSHTarget(t string) (userName, addr string) {
+	host := t
+	if u, h, ok := strings.Cut(t, "@"); ok {
+		userName, host = u, h
+	} else if cur, err := user.Current(); err == nil {
+		userName = cur.Username
+	}
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(strings.Trim(host, "[]"), "22")
+	}
+	return userName, host
+}
+
+// sshAuth collects the key-based methods on offer: the agent, if running,
+// then each --ssh-key, or the usual ~/.ssh identities when none are given.
+func sshAuth() ([]ssh.AuthMethod, error) {
+	var methods []ssh.AuthMethod
+	if sock := os.Getenv("SSH_AUTH_SOCK"); sock != "" {
+		if c, err := net.Dial("unix", sock); err == nil {
+			methods = append(methods, ssh.PublicKeysCallback(agent.NewClient(c).Signers))
+		}
+	}
+	keys := sshKeys
+	explicit := len(keys) > 0
+	if !explicit {
+		home, _ := os.UserHomeDir()
+		for _, name := range []string{"id_ed25519", "id_ecdsa", "id_rsa"} {
+			keys = append(keys, filepath.Join(home, ".ssh", name))
+		}
+	}
+	var signers []ssh.Signer
+	for _, path := range keys {
+		pem, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) && !explicit {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("--ssh-key: %w", err)
+		}
+		signer, err := ssh.ParsePrivateKey(pem)
+		if err != nil {
+			if explicit {
+				return nil, fmt.Errorf("--ssh-key %s: %w", path, err)
+			}
+			slog.Debug("skipping ssh key", "key", path, "err", err) // passphrase-protected; use the agent
+			continue
+		}
+		signers = append(signers, signer)
+	}
+	if len(signers) > 0 {
+		methods = append(methods, ssh.PublicKeys(signers...))
+	}
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("--ssh: no usable key found; start ssh-agent or pass --ssh-key")
+	}
+	return methods, nil
+}
+
+// forwardedFlags renders the remoteFlags the user set, one --name=value per
+// value so repeatable flags survive the trip.
+func forwardedFlags(flags *pflag.FlagSet) string {
+	var args []string
+	flags.Visit(func(f *pflag.Flag) {
+		if !remoteFlags[f.Name] {
+			return
+		}
+		vals := []string{f.Value.String()}
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			vals = sv.GetSlice()
+		}
+		for _, v := range vals {
+			args = append(args, shellQuote("--"+f.Name+"="+v))
+		}
+	})
+	return strings.Join(args, " ")
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// install copies this executable to the remote host unless an identical
+// copy is already there. The remote OS and architecture have to match,
+// since it's the very same binary.
+func (r *remoteHost) install(ctx context.Context) error {
+	out, err := r.run(ctx, "uname -s -m", nil)
+	if err != nil {
+		return err
+	}
+	if got, want := remotePlatform(string(out)), runtime.GOOS+"/"+runtime.GOARCH; got != want {
+		return fmt.Errorf("--ssh: remote is %s but this binary is %s; install gostats there and pass --ssh-bin", got, want)
+	}
+	self, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(self)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	r.bin = "/tmp/gostats-" + hex.EncodeToString(sum[:6])
+	if _, err := r.run(ctx, "test -x "+r.bin, nil); err == nil {
+		return nil
+	}
+	slog.Debug("copying gostats to remote host", "host", sshTarget, "path", r.bin)
+	tmp := r.bin + ".tmp"
+	_, err = r.run(ctx, fmt.Sprintf("cat > %s && chmod 755 %s && mv %s %s", tmp, tmp, tmp, r.bin), bytes.NewReader(data))
+	return err
+}
+
+// remotePlatform maps `uname -s -m` output onto GOOS/GOARCH.
+func remotePlatform(uname string) string {
+	f := strings.Fields(strings.ToLower(uname))
+	if len(f) != 2 {
+		return strings.TrimSpace(uname)
+	}
+	arch := map[string]string{"x86_64": "amd64", "amd64": "amd64", "aarch64": "arm64", "arm64": "arm64", "i686": "386", "i386": "386", "armv7l": "arm"}[f[1]]
+	if arch == "" {
+		arch = f[1]
+	}
+	return f[0] + "/" + arch
+}
+
+// run executes cmd in a new session, feeding it stdin, and returns its
+// stdout. Cancelling ctx closes the session.
+func (r *remoteHost) run(ctx context.Context, cmd string, stdin io.Reader) ([]byte, error) {
+	sess, err := r.client.NewSession()
+	if err != nil {
+		return nil, err
+	}
+	defer sess.Close()
+	var stdout, stderr bytes.Buffer
+	sess.Stdin, sess.Stdout, sess.Stderr = stdin, &stdout, &stderr
+	done := make(chan error, 1)
+	go func() { done <- sess.Run(cmd) }()
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case err = <-done:
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s on %s: %s", strings.Fields(cmd)[0], sshTarget, msg)
+		}
+		return nil, fmt.Errorf("%s on %s: %w", strings.Fields(cmd)[0], sshTarget, err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// collect takes one sample on the remote host. The host name is the
+// remote's unless --hostname overrides it; --label is applied here.
+func (r *remoteHost) collect(ctx context.Context) (Snapshot, error) {
+	var snap Snapshot
+	out, err := r.run(ctx, r.bin+" collect --format json --compact --log-level quiet "+r.args, nil)
+	if err != nil {
+		return snap, err
+	}
+	if err := json.Unmarshal(out, &snap); err != nil {
+		return snap, fmt.Errorf("decoding snapshot from %s: %w", sshTarget, err)
+	}
+	if hostname != "" {
+		snap.Host = hostname
+	}
+	snap.Labels = labels
+	return snap, nil
+}
+
+func (r *remoteHost) close() error {
+	return r.client.Close()
+}
+
+func init() {
+	collectCmd.Flags().StringVar(&sshTarget, "ssh", "", "collect from [user@]host[:port] over SSH instead of locally, running gostats there")
+	collectCmd.Flags().StringArrayVar(&sshKeys, "ssh-key", nil, "private key for --ssh (repeatable; default ssh-agent plus ~/.ssh/id_ed25519, id_ecdsa, id_rsa)")
+	collectCmd.Flags().StringVar(&sshKnownHosts, "ssh-known-hosts", "", "known_hosts file to verify the --ssh host key against (default ~/.ssh/known_hosts)")
+	collectCmd.Flags().DurationVar(&sshTimeout, "ssh-timeout", 10*time.Second, "timeout for connecting and authenticating to the --ssh host")
+	collectCmd.Flags().StringVar(&sshBin, "ssh-bin", "", "path of gostats on the --ssh host; by default this binary is copied there")
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"os/exec"
+	"runtime"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestRemotePlatform(t *testing.T) {
+	// `uname -s -m` as printed by the systems gostats is built for
+	for uname, want := range map[string]string{
+		"Linux x86_64\n":  "linux/amd64",
+		"Linux aarch64\n": "linux/arm64",
+		"Linux armv7l\n":  "linux/arm",
+		"Linux i686\n":    "linux/386",
+		"Darwin arm64\n":  "darwin/arm64",
+		"FreeBSD amd64\n": "freebsd/amd64",
+		"Linux riscv64\n": "linux/riscv64", // unmapped machines pass through
+	} {
+		if got := remotePlatform(uname); got != want {
+			t.Errorf("remotePlatform(%q) = %q, want %q", uname, got, want)
+		}
+	}
+	// Anything else is reported as is, so the mismatch error shows it
+	if got := remotePlatform("MINGW64_NT-10.0-19045 x86_64 Msys\n"); got != "MINGW64_NT-10.0-19045 x86_64 Msys" {
+		t.Errorf("remotePlatform(msys) = %q", got)
+	}
+}
+
+func TestParseSSHTarget(t *testing.T) {
+	for target, want := range map[string][2]string{
+		"ops@db-1":           {"ops", "db-1:22"},
+		"ops@db-1:2222":      {"ops", "db-1:2222"},
+		"ops@[fd00::7]":      {"ops", "[fd00::7]:22"},
+		"ops@[fd00::7]:2222": {"ops", "[fd00::7]:2222"},
+		"deploy@10.0.0.5:22": {"deploy", "10.0.0.5:22"},
+	} {
+		if u, addr := parseSSHTarget(target); u != want[0] || addr != want[1] {
+			t.Errorf("parseSSHTarget(%q) = %q, %q; want %q, %q", target, u, addr, want[0], want[1])
+		}
+	}
+	// Without user@ it's whoever runs gostats
+	if _, addr := parseSSHTarget("db-1"); addr != "db-1:22" {
+		t.Errorf("parseSSHTarget(db-1) addr = %q", addr)
+	}
+}
+
+func TestForwardedFlags(t *testing.T) {
+	fs := pflag.NewFlagSet("collect", pflag.ContinueOnError)
+	fs.StringSlice("disk-path", nil, "")
+	fs.Bool("temps", false, "")
+	fs.String("format", "human", "")
+	fs.String("exclude-fs", "", "")
+	if err := fs.Parse([]string{"--disk-path=/", "--disk-path=/srv/it's mine", "--temps", "--format=json", "--exclude-fs=tmpfs"}); err != nil {
+		t.Fatal(err)
+	}
+	// Output flags such as --format stay local; repeated flags go one by one
+	want := `'--disk-path=/' '--disk-path=/srv/it'\''s mine' '--exclude-fs=tmpfs' '--temps=true'`
+	if got := forwardedFlags(fs); got != want {
+		t.Errorf("forwardedFlags() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs /bin/sh")
+	}
+	// Whatever the remote login shell makes of the quoted form must be the
+	// original argument again
+	for _, arg := range []string{"plain", "with space", "it's", `$(rm -rf /)`, "`id`", `back\slash`, "", "'"} {
+		out, err := exec.Command("sh", "-c", "printf '%s' "+shellQuote(arg)).Output()
+		if err != nil {
+			t.Fatalf("sh -c with %q: %v", arg, err)
+		}
+		if string(out) != arg {
+			t.Errorf("shellQuote(%q) came back from sh as %q", arg, out)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+)
+
+var (
+	statsdAddr   string
+	statsdPrefix string
+	statsdTags   bool
+)
+
+// statsdSink sends one UDP datagram per metric. Percentages and other levels
+// are gauges; cumulative byte counters are sent as counter increments of the
+// change since the previous sample.
+type statsdSink struct {
+	conn    net.Conn
+	prevIn  *uint64
+	prevOut *uint64
+}
+
+func openStatsd(ctx context.Context) (sink, error) {
+	if statsdAddr == "" {
+		return nil, nil
+	}
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "udp", statsdAddr)
+	if err != nil {
+		return nil, fmt.Errorf("statsd: %w", err)
+	}
+	return &statsdSink{conn: conn}, nil
+}
+
+func (s *statsdSink) name() string { return "statsd" }
+
+func (s *statsdSink) write(_ context.Context, snap Snapshot) error {
+	suffix := ""
+	if statsdTags {
+		suffix = "|#host:" + snap.Host
+		for _, k := range sortedLabels(snap.Labels) {
+			suffix += "," + k + ":" + snap.Labels[k]
+		}
+	}
+	var errs []error
+	send := func(metric, value, typ string) {
+		if statsdPrefix != "" {
+			metric = statsdPrefix + "." + metric
+		}
+		if _, err := fmt.Fprintf(s.conn, "%s:%s|%s%s", metric, value, typ, suffix); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	gauge := func(metric string, v *float64) {
+		if v != nil {
+			send(metric, strconv.FormatFloat(*v, 'f', -1, 64), "g")
+		}
+	}
+	counter := func(metric string, prev, cur *uint64) {
+		if prev != nil && cur != nil && *cur >= *prev {
+			send(metric, strconv.FormatUint(*cur-*prev, 10), "c")
+		}
+	}
+
+	gauge("cpu.percent", snap.CPUPercent)
+	gauge("load.load1", snap.Load1)
+	gauge("mem.used_pct", snap.MemUsedPct)
+	gauge("swap.used_pct", snap.SwapUsedPct)
+	for _, d := range snap.Disks {
+		gauge("disk."+graphiteNode(d.Path)+".used_pct", &d.UsedPct)
+	}
+	counter("net.bytes_in", s.prevIn, snap.NetBytesIn)
+	counter("net.bytes_out", s.prevOut, snap.NetBytesOut)
+	s.prevIn, s.prevOut = snap.NetBytesIn, snap.NetBytesOut
+
+	return errors.Join(errs...)
+}
+
+func (s *statsdSink) close() error { return s.conn.Close() }
+
+func init() {
+	registerSink(openStatsd)
+	collectCmd.Flags().StringVar(&statsdAddr, "statsd", "", "send metrics to a StatsD server at host:port over UDP")
+	collectCmd.Flags().StringVar(&statsdPrefix, "statsd-prefix", "gostats", "metric name prefix for --statsd")
+	collectCmd.Flags().BoolVar(&statsdTags, "statsd-tags", false, "add DogStatsD tags (|#host:NAME plus any --label) to --statsd metrics")
+}
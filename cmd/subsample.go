@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/cpu"
+)
+
+var (
+	subsamples   int
+	subsampleMax bool
+
+	// cpuSubs takes the background readings while a stream is running
+	cpuSubs *cpuSubsampler
+)
+
+// minSubsampleGap is the closest together CPU sub-samples may be; shorter
+// readings are dominated by scheduler tick granularity.
+const minSubsampleGap = 10 * time.Millisecond
+
+// subsampleStats is the mean and peak of one sample's CPU readings.
+type subsampleStats struct {
+	mean, max float64
+}
+
+func validateSubsamples() error {
+	if subsamples < 0 {
+		return fmt.Errorf("--subsamples must not be negative, got %d", subsamples)
+	}
+	if subsampleMax && subsamples < 2 {
+		return fmt.Errorf("--subsample-max needs --subsamples 2 or more")
+	}
+	if subsamples < 2 {
+		return nil
+	}
+	if cpuWindow/time.Duration(subsamples) < minSubsampleGap {
+		return fmt.Errorf("--subsamples %d splits --cpu-window %s into readings under %s", subsamples, cpuWindow, minSubsampleGap)
+	}
+	if interval > 0 && interval/time.Duration(subsamples) < minSubsampleGap {
+		return fmt.Errorf("--subsamples %d splits --interval %s into readings under %s", subsamples, interval, minSubsampleGap)
+	}
+	return nil
+}
+
+// subsampleWindow measures CPU over window as --subsamples back-to-back
+// readings, returning the per-reading average in the shape
+// cpu.Percent would (per core with --per-cpu) along with the mean and
+// peak of the aggregate.
+func subsampleWindow(ctx context.Context, window time.Duration) ([]float64, *subsampleStats, error) {
+	var sums []float64
+	var st subsampleStats
+	for i := range subsamples {
+		pcts, err := cpu.PercentWithContext(ctx, window/time.Duration(subsamples), perCPU)
+		if err != nil || len(pcts) == 0 {
+			return nil, nil, err
+		}
+		if sums == nil {
+			sums = make([]float64, len(pcts))
+		}
+		var total float64
+		for j := range min(len(sums), len(pcts)) {
+			sums[j] += pcts[j]
+			total += pcts[j]
+		}
+		agg := total / float64(len(pcts))
+		st.mean += agg
+		if i == 0 || agg > st.max {
+			st.max = agg
+		}
+	}
+	for j := range sums {
+		sums[j] /= float64(subsamples)
+	}
+	st.mean /= float64(subsamples)
+	return sums, &st, nil
+}
+
+// cpuSubsampler reads the aggregate CPU times every interval/--subsamples
+// in the background, so a streaming sample's readings are spread evenly
+// over the interval since the previous one instead of bunched at its start.
+// drain closes off the stretch since the last reading, so together they
+// cover the whole interval whatever the phase between the two clocks.
+type cpuSubsampler struct {
+	mu       sync.Mutex
+	last     *cpu.TimesStat
+	lastAt   time.Time
+	readings []float64
+	done     chan struct{}
+}
+
+func startSubsampler(ctx context.Context, every time.Duration) *cpuSubsampler {
+	c := &cpuSubsampler{last: readCPUTimes(ctx), lastAt: time.Now(), done: make(chan struct{})}
+	go func() {
+		t := time.NewTicker(every / time.Duration(subsamples))
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-c.done:
+				return
+			case <-t.C:
+			}
+			c.mu.Lock()
+			c.read(ctx)
+			c.mu.Unlock()
+		}
+	}()
+	return c
+}
+
+// read records the busy share since the previous reading. Callers hold mu.
+func (c *cpuSubsampler) read(ctx context.Context) {
+	cur, now := readCPUTimes(ctx), time.Now()
+	if c.last != nil && cur != nil {
+		if ct := cpuTimesPct(*c.last, *cur); ct != nil {
+			busy := 100 - ct.Idle
+			if ct.Iowait != nil {
+				busy -= *ct.Iowait
+			}
+			c.readings = append(c.readings, max(busy, 0))
+		}
+	}
+	c.last, c.lastAt = cur, now
+}
+
+// drain returns the stats of the readings taken since the previous call,
+// or nil if there were none yet. A stretch too short to measure is left to
+// the next reading. A nil *cpuSubsampler has no readings.
+func (c *cpuSubsampler) drain(ctx context.Context) *subsampleStats {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	if time.Since(c.lastAt) >= minSubsampleGap {
+		c.read(ctx)
+	}
+	rs := c.readings
+	c.readings = nil
+	c.mu.Unlock()
+	if len(rs) == 0 {
+		return nil
+	}
+	st := subsampleStats{max: rs[0]}
+	for _, r := range rs {
+		st.mean += r
+		st.max = max(st.max, r)
+	}
+	st.mean /= float64(len(rs))
+	return &st
+}
+
+func (c *cpuSubsampler) stop() {
+	if c != nil {
+		close(c.done)
+	}
+}
+
+func init() {
+	collectCmd.Flags().IntVar(&subsamples, "subsamples", 0, "report CPU percent as the average of N readings spread evenly across each interval (or --cpu-window for a single sample) for a steadier value")
+	collectCmd.Flags().BoolVar(&subsampleMax, "subsample-max", false, "also report the highest of the --subsamples readings as cpu_max_percent")
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"text/tabwriter"
+)
+
+var summaryOut bool
+
+// summary accumulates every value of each metric over a run. All values are
+// kept so the percentile is exact; a nil *summary ignores everything.
+type summary struct {
+	order  []string
+	values map[string][]float64
+}
+
+func newSummary() *summary {
+	return &summary{values: map[string][]float64{}}
+}
+
+func (m *summary) add(s Snapshot) {
+	if m == nil {
+		return
+	}
+	for _, mv := range metricValues(s) {
+		if _, ok := m.values[mv.Name]; !ok {
+			m.order = append(m.order, mv.Name)
+		}
+		m.values[mv.Name] = append(m.values[mv.Name], mv.Value)
+	}
+}
+
+// print writes a min/max/mean/p95 table with one row per metric seen.
+func (m *summary) print(w io.Writer) error {
+	if m == nil || len(m.order) == 0 {
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "METRIC\tN\tMIN\tMAX\tMEAN\tP95")
+	for _, name := range m.order {
+		vs := slices.Clone(m.values[name])
+		slices.Sort(vs)
+		var sum float64
+		for _, v := range vs {
+			sum += v
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%.2f\t%.2f\t%.2f\t%.2f\n",
+			name, len(vs), vs[0], vs[len(vs)-1], sum/float64(len(vs)), percentile(vs, 95))
+	}
+	return tw.Flush()
+}
+
+// percentile returns the nearest-rank p-th percentile of sorted values.
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank-1, 0)]
+}
+
+func init() {
+	collectCmd.Flags().BoolVar(&summaryOut, "summary", false, "print min/max/mean/p95 per metric to stderr when the run ends")
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	syslogLocal    bool
+	syslogAddr     string
+	syslogAppName  string
+	syslogFacility string
+)
+
+// syslogTimeout bounds dialing and each write to a remote syslog server.
+const syslogTimeout = 5 * time.Second
+
+// syslogFacilities maps --syslog-facility names to RFC 5424 facility codes.
+var syslogFacilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5, "lpr": 6, "news": 7,
+	"uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19, "local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+// syslogSeverityInfo is the severity every snapshot is logged at.
+const syslogSeverityInfo = 6
+
+// syslogSink writes each snapshot as an RFC 5424 message with its JSON as
+// the MSG part, to the local syslog socket or to --syslog-addr. It frames
+// the messages itself: log/syslog only speaks the older BSD format, which
+// has neither a year nor sub-second timestamps. A failed write drops the
+// connection so the next sample dials afresh.
+type syslogSink struct {
+	network, addr string // empty for the local socket
+	facility      int
+	conn          net.Conn
+}
+
+func openSyslog(ctx context.Context) (sink, error) {
+	if !syslogLocal && syslogAddr == "" {
+		return nil, nil
+	}
+	fac, ok := syslogFacilities[strings.ToLower(syslogFacility)]
+	if !ok {
+		return nil, fmt.Errorf("unknown --syslog-facility %q (want e.g. daemon, user or local0..local7)", syslogFacility)
+	}
+	if syslogAppName == "" || len(syslogAppName) > 48 || strings.ContainsFunc(syslogAppName, func(r rune) bool { return r <= ' ' || r > '~' }) {
+		return nil, fmt.Errorf("--syslog-app-name must be 1 to 48 printable ASCII characters without spaces, got %q", syslogAppName)
+	}
+	s := &syslogSink{facility: fac}
+	if syslogAddr != "" {
+		network, addr, err := parseSyslogAddr(syslogAddr)
+		if err != nil {
+			return nil, err
+		}
+		s.network, s.addr = network, addr
+	}
+	if err := s.dial(ctx); err != nil {
+		return nil, fmt.Errorf("syslog: %w", err)
+	}
+	return s, nil
+}
+
+// parseSyslogAddr accepts udp://host:port, tcp://host:port or a bare
+// host[:port], which means UDP. The port defaults to 514 for UDP and 601
+// for TCP.
+func parseSyslogAddr(raw string) (network, addr string, err error) {
+	network, addr = "udp", raw
+	if scheme, rest, ok := strings.Cut(raw, "://"); ok {
+		network, addr = scheme, rest
+	}
+	if network != "udp" && network != "tcp" {
+		return "", "", fmt.Errorf("--syslog-addr %q: scheme must be udp or tcp", raw)
+	}
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		port := "514"
+		if network == "tcp" {
+			port = "601"
+		}
+		addr = net.JoinHostPort(strings.Trim(addr, "[]"), port)
+	}
+	return network, addr, nil
+}
+
+func (s *syslogSink) dial(ctx context.Context) error {
+	var err error
+	if s.addr == "" {
+		s.conn, err = dialLocalSyslog()
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, syslogTimeout)
+	defer cancel()
+	s.conn, err = (&net.Dialer{}).DialContext(ctx, s.network, s.addr)
+	return err
+}
+
+func (s *syslogSink) name() string { return "syslog" }
+
+func (s *syslogSink) write(ctx context.Context, snap Snapshot) error {
+	if s.conn == nil {
+		if err := s.dial(ctx); err != nil {
+			return err
+		}
+	}
+	payload, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	msg := formatSyslog(s.facility, snap, payload)
+	if s.network == "tcp" {
+		// RFC 6587 octet counting, so JSON can't be mistaken for a frame end
+		msg = append([]byte(strconv.Itoa(len(msg))+" "), msg...)
+	}
+	s.conn.SetWriteDeadline(time.Now().Add(syslogTimeout))
+	if _, err := s.conn.Write(msg); err != nil {
+		s.conn.Close()
+		s.conn = nil
+		return err
+	}
+	return nil
+}
+
+// formatSyslog renders an RFC 5424 message:
+// <PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID - MSG
+func formatSyslog(facility int, snap Snapshot, payload []byte) []byte {
+	host := strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' {
+			return -1
+		}
+		return r
+	}, snap.Host)
+	if host == "" {
+		host = "-"
+	}
+	if len(host) > 255 {
+		host = host[:255]
+	}
+	head := fmt.Sprintf("<%d>1 %s %s %s %d snapshot - ",
+		facility*8+syslogSeverityInfo,
+		snap.Timestamp.Format("2006-01-02T15:04:05.000000Z07:00"),
+		host, syslogAppName, os.Getpid())
+	return append([]byte(head), payload...)
+}
+
+func (s *syslogSink) close() error {
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Close()
+}
+
+func init() {
+	registerSink(openSyslog)
+	collectCmd.Flags().BoolVar(&syslogLocal, "syslog", false, "also write each snapshot as an RFC 5424 syslog message with JSON in the MSG part, to the local syslog socket (Unix)")
+	collectCmd.Flags().StringVar(&syslogAddr, "syslog-addr", "", "send the --syslog messages to this server instead: udp://host:port, tcp://host:port or host[:port] for UDP (implies --syslog)")
+	collectCmd.Flags().StringVar(&syslogAppName, "syslog-app-name", "gostats", "APP-NAME field of --syslog messages")
+	collectCmd.Flags().StringVar(&syslogFacility, "syslog-facility", "daemon", "facility of --syslog messages: kern, user, mail, daemon, auth, syslog, lpr, news, uucp, cron, authpriv, ftp or local0..local7")
+}
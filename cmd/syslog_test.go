@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSyslogAddr(t *testing.T) {
+	check := func(raw, wantNetwork, wantAddr string) {
+		t.Helper()
+		network, addr, err := parseSyslogAddr(raw)
+		if err != nil || network != wantNetwork || addr != wantAddr {
+			t.Errorf("parseSyslogAddr(%q) = %q, %q, %v; want %q, %q", raw, network, addr, err, wantNetwork, wantAddr)
+		}
+	}
+	// A bare host means UDP, as in rsyslog's @host
+	check("logs.example.com", "udp", "logs.example.com:514")
+	check("logs.example.com:1514", "udp", "logs.example.com:1514")
+	check("udp://10.0.0.1", "udp", "10.0.0.1:514")
+	// TCP defaults to 601, the syslog-conn port of RFC 3195
+	check("tcp://10.0.0.1", "tcp", "10.0.0.1:601")
+	check("tcp://10.0.0.1:6514", "tcp", "10.0.0.1:6514")
+	check("[fd00::1]", "udp", "[fd00::1]:514")
+	check("tcp://[fd00::1]:10514", "tcp", "[fd00::1]:10514")
+
+	for _, raw := range []string{"tls://10.0.0.1:6514", "unix:///dev/log"} {
+		if _, _, err := parseSyslogAddr(raw); err == nil {
+			t.Errorf("parseSyslogAddr(%q) succeeded, want a scheme error", raw)
+		}
+	}
+}
+
+func TestFormatSyslog(t *testing.T) {
+	old := syslogAppName
+	t.Cleanup(func() { syslogAppName = old })
+	syslogAppName = "gostats"
+
+	snap := Snapshot{Timestamp: time.Date(2026, 3, 1, 12, 0, 1, 234567000, time.UTC), Host: "web 1\tprod"}
+	got := string(formatSyslog(syslogFacilities["local3"], snap, []byte(`{"host":"web-1"}`)))
+	// local3 (19) * 8 + info (6) = 158; spaces and tabs can't appear in HOSTNAME
+	want := fmt.Sprintf(`<158>1 2026-03-01T12:00:01.234567Z web1prod gostats %d snapshot - {"host":"web-1"}`, os.Getpid())
+	if got != want {
+		t.Errorf("formatSyslog() =\n%s\nwant\n%s", got, want)
+	}
+
+	snap.Host = ""
+	if got := string(formatSyslog(3, snap, nil)); !strings.Contains(got, "Z - gostats ") {
+		t.Errorf("empty host not written as the nil value: %s", got)
+	}
+}
+
+// TestSyslogTCPFraming sends two samples over TCP and reads them back by
+// octet count, as rsyslog's imtcp would.
+func TestSyslogTCPFraming(t *testing.T) {
+	oldAddr, oldApp, oldFac := syslogAddr, syslogAppName, syslogFacility
+	t.Cleanup(func() { syslogAddr, syslogAppName, syslogFacility = oldAddr, oldApp, oldFac })
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	syslogAddr, syslogAppName, syslogFacility = "tcp://"+ln.Addr().String(), "gostats", "daemon"
+
+	ctx := context.Background()
+	s, err := openSyslog(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.close()
+	conn, err := ln.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// Frames are counted, so spaces and digits in the MSG can't split one
+	snaps := []Snapshot{
+		{Timestamp: time.Now(), Host: "web-1", Labels: map[string]string{"note": "42 rack b"}},
+		{Timestamp: time.Now(), Host: "web-1", CPUPercent: ptr(42.0)},
+	}
+	for _, snap := range snaps {
+		if err := s.write(ctx, snap); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	r := bufio.NewReader(conn)
+	for i, want := range snaps {
+		size, err := r.ReadString(' ')
+		if err != nil {
+			t.Fatalf("message %d: reading length: %v", i+1, err)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(size))
+		if err != nil {
+			t.Fatalf("message %d: bad octet count %q", i+1, size)
+		}
+		msg := make([]byte, n)
+		if _, err := io.ReadFull(r, msg); err != nil {
+			t.Fatalf("message %d: %v", i+1, err)
+		}
+		if !strings.HasPrefix(string(msg), "<30>1 ") {
+			t.Errorf("message %d: header %.20q, want daemon.info (<30>)", i+1, msg)
+		}
+		_, payload, _ := strings.Cut(string(msg), " snapshot - ")
+		var got Snapshot
+		if err := json.Unmarshal([]byte(payload), &got); err != nil {
+			t.Fatalf("message %d: MSG is not a snapshot: %v", i+1, err)
+		}
+		if got.Host != want.Host || got.Labels["note"] != want.Labels["note"] {
+			t.Errorf("message %d: got %+v, want %+v", i+1, got, want)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+//go:build !windows
+
+package cmd
+
+import (
+	"errors"
+	"net"
+)
+
+// dialLocalSyslog connects to the syslog daemon's socket, trying the same
+// paths and socket types as log/syslog.
+func dialLocalSyslog() (net.Conn, error) {
+	for _, network := range []string{"unixgram", "unix"} {
+		for _, path := range []string{"/dev/log", "/var/run/syslog", "/var/run/log"} {
+			if conn, err := net.Dial(network, path); err == nil {
+				return conn, nil
+			}
+		}
+	}
+	return nil, errors.New("no local syslog socket found; is a syslog daemon running? (or use --syslog-addr)")
+}
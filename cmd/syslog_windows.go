@@ -0,0 +1,11 @@
+package cmd
+
+import (
+	"errors"
+	"net"
+)
+
+// dialLocalSyslog fails: Windows has no local syslog daemon to talk to.
+func dialLocalSyslog() (net.Conn, error) {
+	return nil, errors.New("Windows has no local syslog; use --syslog-addr")
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+// outputFormat, when set, gives --output a format of its own and keeps
+// --format on stdout, so one run can show the table and archive JSON.
+var outputFormat string
+
+// resolveOutputFormat checks --output-format and returns the format the
+// --output file is written in.
+func resolveOutputFormat(outFmt string) (string, error) {
+	if outputFormat == "" {
+		return outFmt, nil
+	}
+	if outputPath == "" {
+		return "", fmt.Errorf("--output-format needs --output")
+	}
+	if !slices.Contains(formats, outputFormat) {
+		return "", fmt.Errorf("unknown --output-format %q (want %s)", outputFormat, strings.Join(formats, ", "))
+	}
+	return outputFormat, nil
+}
+
+// emitters renders every sample to each destination: the --output file
+// and, with --output-format, stdout as well. Samples are collected once
+// however many there are.
+type emitters []*emitter
+
+func newEmitters(w io.Writer, outFmt, fileFmt string, stream bool) emitters {
+	if outputFormat == "" {
+		return emitters{newEmitter(w, outFmt, stream)}
+	}
+	file := newEmitter(w, fileFmt, stream)
+	file.plain = true // color codes are for the terminal
+	return emitters{newEmitter(os.Stdout, outFmt, stream), file}
+}
+
+func (es emitters) begin() error {
+	for _, e := range es {
+		if err := e.begin(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (es emitters) emit(s Snapshot) error {
+	for _, e := range es {
+		if err := e.emit(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func init() {
+	collectCmd.Flags().StringVar(&outputFormat, "output-format", "", "format for the --output file, which then gets a copy while stdout keeps --format (e.g. a table on screen and JSON on disk)")
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/shirou/gopsutil/v4/sensors"
+)
+
+var temps bool
+
+// SensorTemp is one temperature sensor reading in degrees Celsius. High and
+// Critical are the thresholds reported by the sensor, or 0 when unknown.
+type SensorTemp struct {
+	Key      string  `json:"key"`
+	Current  float64 `json:"current" unit:"celsius"`
+	High     float64 `json:"high,omitempty" unit:"celsius"`
+	Critical float64 `json:"critical,omitempty" unit:"celsius"`
+}
+
+// readTemps returns every sensor the platform exposes. Some platforms return
+// partial results alongside warnings, so readings are kept whenever present.
+func readTemps(ctx context.Context) []SensorTemp {
+	ts, _ := sensors.TemperaturesWithContext(ctx)
+	out := make([]SensorTemp, 0, len(ts))
+	for _, t := range ts {
+		out = append(out, SensorTemp{Key: t.SensorKey, Current: t.Temperature, High: t.High, Critical: t.Critical})
+	}
+	return out
+}
+
+// hottestTemp renders the hottest sensor as "key=65.0C", or "-" if none.
+func hottestTemp(ts []SensorTemp) string {
+	if len(ts) == 0 {
+		return "-"
+	}
+	hot := ts[0]
+	for _, t := range ts[1:] {
+		if t.Current > hot.Current {
+			hot = t
+		}
+	}
+	return hot.Key + "=" + fmtFloat(hot.Current, 1) + "C"
+}
+
+func init() {
+	collectCmd.Flags().BoolVar(&temps, "temps", false, "collect temperature sensor readings")
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+var maxCPU, maxMemPct, maxDiskPct, maxInodePct, maxDropRate float64
+
+// breach describes one metric over its configured limit.
+type breach struct {
+	Metric string
+	Flag   string
+	Value  float64
+	Limit  float64
+}
+
+func (b breach) String() string {
+	return fmt.Sprintf("%s=%.1f exceeds --%s %g", b.Metric, b.Value, b.Flag, b.Limit)
+}
+
+// checkThresholds compares s against the --max-* flags. A limit of 0
+// disables that check, as does a metric that wasn't collected.
+func checkThresholds(s Snapshot) []breach {
+	var out []breach
+	check := func(metric, flag string, v *float64, limit float64) {
+		if limit > 0 && v != nil && *v > limit {
+			out = append(out, breach{Metric: metric, Flag: flag, Value: *v, Limit: limit})
+		}
+	}
+	check("cpu_percent", "max-cpu", s.CPUPercent, maxCPU)
+	check("mem_used_pct", "max-mem-pct", s.MemUsedPct, maxMemPct)
+	for _, d := range s.Disks {
+		check("disk_used_pct["+d.Path+"]", "max-disk-pct", &d.UsedPct, maxDiskPct)
+		check("disk_inodes_used_pct["+d.Path+"]", "max-inode-pct", d.InodesUsedPct, maxInodePct)
+	}
+	check("net_drop_in_per_sec", "max-drop-rate", s.NetDropInRate, maxDropRate)
+	check("net_drop_out_per_sec", "max-drop-rate", s.NetDropOutRate, maxDropRate)
+	return out
+}
+
+func thresholdError(bs []breach) error {
+	parts := make([]string, len(bs))
+	for i, b := range bs {
+		parts[i] = b.String()
+	}
+	return fmt.Errorf("threshold breached: %s", strings.Join(parts, "; "))
+}
+
+func init() {
+	collectCmd.Flags().Float64Var(&maxCPU, "max-cpu", 0, "exit with status 2 if CPU percent on the final sample exceeds this (0 = off)")
+	collectCmd.Flags().Float64Var(&maxMemPct, "max-mem-pct", 0, "exit with status 2 if memory used percent on the final sample exceeds this (0 = off)")
+	collectCmd.Flags().Float64Var(&maxDropRate, "max-drop-rate", 0, "exit with status 2 if dropped packets per second in either direction exceed this on the final streaming sample (0 = off)")
+	collectCmd.Flags().Float64Var(&maxDiskPct, "max-disk-pct", 0, "exit with status 2 if any disk used percent on the final sample exceeds this (0 = off)")
+	collectCmd.Flags().Float64Var(&maxInodePct, "max-inode-pct", 0, "exit with status 2 if any disk inode used percent on the final sample exceeds this (0 = off)")
+}
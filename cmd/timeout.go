@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+var collectTimeout time.Duration
+
+// warnTimedOut reports a collection cycle cut short by --collect-timeout.
+// Whatever hadn't been gathered by then is simply missing from the sample.
+func warnTimedOut(ctx context.Context) {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		slog.Warn("collection exceeded --collect-timeout; sample is partial", "timeout", collectTimeout)
+	}
+}
+
+func init() {
+	collectCmd.Flags().DurationVar(&collectTimeout, "collect-timeout", 0, "give up on metrics still being collected after this long (e.g. 3s) and emit a partial sample (0 = wait indefinitely)")
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// tsFormat is --timestamp-format: "", "rfc3339", "epoch", "epoch-ms" or a
+// Go time layout. Empty keeps the defaults of HH:MM:SS in the human table,
+// RFC3339 in CSV and RFC3339 with nanoseconds in JSON.
+var tsFormat string
+
+var (
+	utc      bool
+	timezone string
+
+	// tsLocation is the zone snapshots are stamped in; nil means local time.
+	tsLocation *time.Location
+)
+
+// resolveTimezone sets tsLocation from --utc or --timezone.
+func resolveTimezone() error {
+	switch {
+	case utc && timezone != "":
+		return errors.New("--utc and --timezone are mutually exclusive")
+	case utc:
+		tsLocation = time.UTC
+	case timezone != "":
+		loc, err := time.LoadLocation(timezone)
+		if err != nil {
+			return fmt.Errorf("invalid --timezone: %w", err)
+		}
+		tsLocation = loc
+	}
+	return nil
+}
+
+// formatTimestamp renders t for text outputs, using def when no
+// --timestamp-format was given.
+func formatTimestamp(t time.Time, def string) string {
+	switch tsFormat {
+	case "":
+		return t.Format(def)
+	case "rfc3339":
+		return t.Format(time.RFC3339)
+	case "epoch":
+		return strconv.FormatInt(t.Unix(), 10)
+	case "epoch-ms":
+		return strconv.FormatInt(t.UnixMilli(), 10)
+	}
+	return t.Format(tsFormat)
+}
+
+// jsonTimestamp returns the value to encode as "ts": a number for the epoch
+// formats and a string otherwise.
+func jsonTimestamp(t time.Time) any {
+	switch tsFormat {
+	case "epoch":
+		return t.Unix()
+	case "epoch-ms":
+		return t.UnixMilli()
+	}
+	return formatTimestamp(t, time.RFC3339Nano)
+}
+
+// MarshalJSON applies --timestamp-format to the "ts" key. The outer Timestamp
+// field shadows the embedded one, so every other field encodes unchanged.
+//
+// Floats are rounded to --precision here too, so every JSON consumer (the
+// output, push and MQTT payloads, SQLite rows) sees the same numbers.
+func (s Snapshot) MarshalJSON() ([]byte, error) {
+	type plain Snapshot
+	var b []byte
+	var err error
+	if tsFormat == "" {
+		b, err = json.Marshal(plain(s))
+	} else {
+		b, err = json.Marshal(struct {
+			Timestamp any `json:"ts"`
+			plain
+		}{jsonTimestamp(s.Timestamp), plain(s)})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return roundJSON(b), nil
+}
+
+// UnmarshalJSON reads snapshots saved by collect --json, whatever
+// --timestamp-format they were written with: RFC3339, epoch seconds or
+// milliseconds, or the layout currently set by --timestamp-format.
+func (s *Snapshot) UnmarshalJSON(b []byte) error {
+	type plain Snapshot
+	aux := struct {
+		Timestamp json.RawMessage `json:"ts"`
+		*plain
+	}{plain: (*plain)(s)}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+	if len(aux.Timestamp) == 0 {
+		return nil
+	}
+	var epoch json.Number
+	if err := json.Unmarshal(aux.Timestamp, &epoch); err == nil {
+		n, err := epoch.Int64()
+		if err != nil {
+			return fmt.Errorf("ts: %w", err)
+		}
+		// Seconds won't reach 1e12 for another 30,000 years
+		if n >= 1e12 {
+			s.Timestamp = time.UnixMilli(n)
+		} else {
+			s.Timestamp = time.Unix(n, 0)
+		}
+		return nil
+	}
+	var str string
+	if err := json.Unmarshal(aux.Timestamp, &str); err != nil {
+		return fmt.Errorf("ts: %w", err)
+	}
+	t, err := time.Parse(time.RFC3339Nano, str)
+	if err != nil && tsFormat != "" && tsFormat != "rfc3339" {
+		t, err = time.Parse(tsFormat, str)
+	}
+	if err != nil {
+		return fmt.Errorf("ts: %w", err)
+	}
+	s.Timestamp = t
+	return nil
+}
+
+func init() {
+	collectCmd.Flags().BoolVar(&utc, "utc", false, "record and format timestamps in UTC")
+	collectCmd.Flags().StringVar(&timezone, "timezone", "", "record and format timestamps in this IANA zone (e.g. America/New_York)")
+	collectCmd.Flags().StringVar(&tsFormat, "timestamp-format", "", "timestamp format: rfc3339, epoch, epoch-ms or a Go layout (default HH:MM:SS in tables, RFC3339 in JSON/CSV)")
+}
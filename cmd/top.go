@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/process"
+)
+
+var (
+	topN    int
+	topSort string
+)
+
+// ProcStat is one entry in the top-N process list.
+type ProcStat struct {
+	PID        int32   `json:"pid"`
+	Name       string  `json:"name"`
+	CPUPercent float64 `json:"cpu_percent"`
+	MemMB      float64 `json:"mem_mb"`
+}
+
+// procCache keeps process handles between samples. gopsutil computes a
+// process's CPU percent relative to the previous call on the same handle, so
+// reusing them makes each reading cover the time since the last sample.
+//
+// procCacheMu is held for a whole topProcesses call: a run abandoned by
+// --collect-timeout may still be using the cache when the next sample's
+// starts, and the handles themselves aren't safe to share either.
+var (
+	procCacheMu sync.Mutex
+	procCache   = map[int32]*process.Process{}
+)
+
+// topProcesses returns the n busiest processes ordered by --top-sort.
+// Processes that exit between enumeration and the stat reads are skipped.
+func topProcesses(ctx context.Context, n int) ([]ProcStat, error) {
+	procCacheMu.Lock()
+	defer procCacheMu.Unlock()
+	pids, err := process.PidsWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int32]bool, len(pids))
+	primed := false
+	for _, pid := range pids {
+		seen[pid] = true
+		if _, ok := procCache[pid]; ok {
+			continue
+		}
+		p, err := process.NewProcessWithContext(ctx, pid)
+		if err != nil {
+			continue // already gone
+		}
+		p.PercentWithContext(ctx, 0) // seed the CPU baseline
+		procCache[pid] = p
+		primed = true
+	}
+	for pid := range procCache {
+		if !seen[pid] {
+			delete(procCache, pid)
+		}
+	}
+	// Newly seen processes have no baseline yet; give them a short window
+	// so the first sample isn't all zeros.
+	if primed {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+
+	stats := make([]ProcStat, 0, len(procCache))
+	for pid, p := range procCache {
+		pct, err := p.PercentWithContext(ctx, 0)
+		if err != nil {
+			slog.Debug("skipping process", "pid", pid, "err", err)
+			delete(procCache, pid)
+			continue
+		}
+		mi, err := p.MemoryInfoWithContext(ctx)
+		if err != nil {
+			slog.Debug("skipping process", "pid", pid, "err", err)
+			delete(procCache, pid)
+			continue
+		}
+		name, _ := p.NameWithContext(ctx)
+		stats = append(stats, ProcStat{
+			PID:        pid,
+			Name:       name,
+			CPUPercent: pct,
+			MemMB:      float64(mi.RSS) / (1024 * 1024),
+		})
+	}
+
+	slices.SortFunc(stats, func(a, b ProcStat) int {
+		if topSort == "mem" {
+			return cmp.Or(cmp.Compare(b.MemMB, a.MemMB), cmp.Compare(b.CPUPercent, a.CPUPercent))
+		}
+		return cmp.Or(cmp.Compare(b.CPUPercent, a.CPUPercent), cmp.Compare(b.MemMB, a.MemMB))
+	})
+	if len(stats) > n {
+		stats = stats[:n]
+	}
+	return stats, nil
+}
+
+// humanProcTable renders the process list as an indented, tab-separated
+// secondary table printed beneath a sample row.
+func humanProcTable(procs []ProcStat) string {
+	var b strings.Builder
+	b.WriteString("  PID\tNAME\tCPU%\tMEM(MB)\n")
+	for _, p := range procs {
+		fmt.Fprintf(&b, "  %d\t%s\t%s\t%s\n", p.PID, p.Name, fmtFloat(p.CPUPercent, 1), fmtFloat(p.MemMB, 1))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func init() {
+	collectCmd.Flags().IntVar(&topN, "top", 0, "also list the top N processes by CPU and memory")
+	collectCmd.Flags().StringVar(&topSort, "top-sort", "cpu", "order for --top: cpu or mem")
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// sizeUnits is --units: "" keeps the fixed mem_*_mb / disk *_gb fields only;
+// bytes, mb, gb or auto also add formatted and raw-byte size fields.
+var sizeUnits string
+
+func resolveUnits() error {
+	switch sizeUnits {
+	case "", "bytes", "mb", "gb", "auto":
+		return nil
+	}
+	return fmt.Errorf("unknown --units %q (want bytes, mb, gb or auto)", sizeUnits)
+}
+
+// formatSize renders b in --units with its unit label: "536870912 B",
+// "512.0 MiB", "0.5 GiB", or for auto the largest unit that keeps the value
+// at or above 1.
+func formatSize(b uint64) string {
+	v, unit := sizeIn(b)
+	if unit == "B" {
+		return strconv.FormatUint(b, 10) + " B"
+	}
+	return fmtFloat(v, 1) + " " + unit
+}
+
+func sizeIn(b uint64) (float64, string) {
+	const k = 1024
+	switch sizeUnits {
+	case "bytes":
+		return float64(b), "B"
+	case "mb":
+		return float64(b) / (k * k), "MiB"
+	case "gb":
+		return float64(b) / (k * k * k), "GiB"
+	}
+	v := float64(b)
+	for _, unit := range []string{"B", "KiB", "MiB", "GiB"} {
+		if v < k {
+			return v, unit
+		}
+		v /= k
+	}
+	return v, "TiB"
+}
+
+// memSizes fills the --units size fields for memory and swap.
+func (s *Snapshot) memSizes(used, total uint64) {
+	s.MemUsedBytes, s.MemTotalBytes = &used, &total
+	s.MemUsed, s.MemTotal = formatSize(used), formatSize(total)
+}
+
+func (s *Snapshot) swapSizes(used, total uint64) {
+	s.SwapUsedBytes, s.SwapTotalBytes = &used, &total
+	s.SwapUsed, s.SwapTotal = formatSize(used), formatSize(total)
+}
+
+// humanMemHeader and humanMem are the MEM_USED/TOTAL column: MB as always,
+// or --units (auto labels each value since units can differ).
+func humanMemHeader() string {
+	switch sizeUnits {
+	case "":
+		return "MEM_USED/TOTAL(MB)"
+	case "auto":
+		return "MEM_USED/TOTAL"
+	}
+	_, unit := sizeIn(0)
+	return "MEM_USED/TOTAL(" + unit + ")"
+}
+
+func (s Snapshot) humanMem() string {
+	if sizeUnits == "" || s.MemUsedBytes == nil || s.MemTotalBytes == nil {
+		return optUint(s.MemUsedMB) + "/" + optUint(s.MemTotalMB)
+	}
+	cell := func(b uint64) string {
+		v, unit := sizeIn(b)
+		if sizeUnits == "auto" {
+			return fmtFloat(v, 1) + unit
+		}
+		if unit == "B" {
+			return strconv.FormatUint(b, 10)
+		}
+		return fmtFloat(v, 1)
+	}
+	return cell(*s.MemUsedBytes) + "/" + cell(*s.MemTotalBytes)
+}
+
+func init() {
+	collectCmd.Flags().StringVar(&sizeUnits, "units", "", "also express memory and disk sizes in bytes, mb, gb or auto, with raw *_bytes fields in JSON (default: MB for memory, GB for disk)")
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"strconv"
+
+	"github.com/shirou/gopsutil/v4/host"
+)
+
+var users bool
+
+// UserSession is one logged-in session, as listed by who(1).
+type UserSession struct {
+	User     string `json:"user"`
+	Terminal string `json:"terminal"`
+	Host     string `json:"host,omitempty"`
+}
+
+// collectUsers counts sessions and, with --verbose, lists them. Containers
+// and some platforms have no utmp; that reads as zero sessions.
+func collectUsers(ctx context.Context) (func(*Snapshot), error) {
+	us, err := host.UsersWithContext(ctx)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	var sessions []UserSession
+	if verbose {
+		for _, u := range us {
+			sessions = append(sessions, UserSession{User: u.User, Terminal: u.Terminal, Host: u.Host})
+		}
+	}
+	return func(s *Snapshot) {
+		s.UserCount = ptr(uint64(len(us)))
+		s.Users = sessions
+	}, nil
+}
+
+func (s Snapshot) humanUsers() string {
+	if s.UserCount == nil {
+		return "-"
+	}
+	return strconv.FormatUint(*s.UserCount, 10)
+}
+
+func init() {
+	collectCmd.Flags().BoolVar(&users, "users", false, "count logged-in sessions (listed individually with --verbose)")
+}
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/spf13/cobra"
+)
+
+var watchInterval time.Duration
+
+// sparkBlocks are the eight heights used to draw the network graph.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// dashboard holds what the watch screen draws: the latest sample and a
+// bounded history of network rates for the scrolling graph.
+type dashboard struct {
+	screen tcell.Screen
+	snap   *Snapshot
+	netIn  []float64
+	netOut []float64
+}
+
+func (d *dashboard) record(s Snapshot) {
+	d.snap = &s
+	w, _ := d.screen.Size()
+	keep := max(w-12, 1)
+	push := func(hist []float64, v *float64) []float64 {
+		if v == nil {
+			return hist
+		}
+		hist = append(hist, *v)
+		if len(hist) > keep {
+			hist = hist[len(hist)-keep:]
+		}
+		return hist
+	}
+	d.netIn = push(d.netIn, s.NetRateInBps)
+	d.netOut = push(d.netOut, s.NetRateOutBps)
+}
+
+func (d *dashboard) text(x, y int, style tcell.Style, s string) {
+	for _, r := range s {
+		d.screen.SetContent(x, y, r, nil, style)
+		x++
+	}
+}
+
+// gauge draws "LABEL [#####.....] 45.2%" colored by the same 70/90
+// thresholds as the table output.
+func (d *dashboard) gauge(y int, label string, pct *float64) {
+	w, _ := d.screen.Size()
+	barWidth := max(w-24, 10)
+	d.text(0, y, tcell.StyleDefault.Bold(true), fmt.Sprintf("%-10s", label))
+	if pct == nil {
+		d.text(11, y, tcell.StyleDefault, "-")
+		return
+	}
+	style := tcell.StyleDefault.Foreground(tcell.ColorGreen)
+	switch {
+	case *pct > 90:
+		style = style.Foreground(tcell.ColorRed)
+	case *pct > 70:
+		style = style.Foreground(tcell.ColorYellow)
+	}
+	filled := int(*pct / 100 * float64(barWidth))
+	filled = min(max(filled, 0), barWidth)
+	d.text(11, y, tcell.StyleDefault, "[")
+	d.text(12, y, style, strings.Repeat("#", filled))
+	d.text(12+filled, y, tcell.StyleDefault, strings.Repeat(".", barWidth-filled)+"]")
+	d.text(14+barWidth, y, tcell.StyleDefault, fmt.Sprintf("%5.1f%%", *pct))
+}
+
+// spark draws a history as a sparkline scaled to its own maximum.
+func (d *dashboard) spark(y int, label string, hist []float64) {
+	d.text(0, y, tcell.StyleDefault.Bold(true), fmt.Sprintf("%-10s", label))
+	var peak float64
+	for _, v := range hist {
+		peak = max(peak, v)
+	}
+	for i, v := range hist {
+		idx := 0
+		if peak > 0 {
+			idx = int(v / peak * float64(len(sparkBlocks)-1))
+		}
+		d.screen.SetContent(11+i, y, sparkBlocks[idx], nil, tcell.StyleDefault.Foreground(tcell.ColorTeal))
+	}
+	last := "-"
+	if len(hist) > 0 {
+		last = fmt.Sprintf("%.0f B/s (peak %.0f)", hist[len(hist)-1], peak)
+	}
+	d.text(0, y+1, tcell.StyleDefault.Dim(true), "          "+last)
+}
+
+func (d *dashboard) draw() {
+	d.screen.Clear()
+	if d.snap == nil {
+		d.text(0, 0, tcell.StyleDefault, "collecting...")
+		d.screen.Show()
+		return
+	}
+	s := d.snap
+	d.text(0, 0, tcell.StyleDefault.Bold(true),
+		fmt.Sprintf("%s  %s  up %s  %s", s.Host, s.OS, FormatUptime(s.UptimeSec), formatTimestamp(s.Timestamp, "15:04:05")))
+	y := 2
+	d.gauge(y, "CPU", s.CPUPercent)
+	y++
+	d.gauge(y, "MEM", s.MemUsedPct)
+	y++
+	d.gauge(y, "SWAP", s.SwapUsedPct)
+	y++
+	for _, disk := range s.Disks {
+		d.gauge(y, "DISK "+disk.Path, &disk.UsedPct)
+		y++
+	}
+	if s.Load1 != nil {
+		d.text(0, y, tcell.StyleDefault.Bold(true), fmt.Sprintf("%-10s", "LOAD"))
+		d.text(11, y, tcell.StyleDefault, fmt.Sprintf("%.2f %.2f %.2f", *s.Load1, *s.Load5, *s.Load15))
+		y++
+	}
+	y++
+	d.spark(y, "NET IN", d.netIn)
+	d.spark(y+3, "NET OUT", d.netOut)
+	_, h := d.screen.Size()
+	d.text(0, h-1, tcell.StyleDefault.Dim(true), "q or ctrl-c to quit")
+	d.screen.Show()
+}
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Show a live full-screen dashboard",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if watchInterval <= 0 {
+			return fmt.Errorf("--interval must be positive")
+		}
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+
+		screen, err := tcell.NewScreen()
+		if err != nil {
+			return err
+		}
+		if err := screen.Init(); err != nil {
+			return err
+		}
+		// Fini restores the terminal; it must run on every exit path
+		defer screen.Fini()
+
+		// In raw mode ctrl-c arrives as a key event rather than SIGINT
+		go func() {
+			for {
+				switch ev := screen.PollEvent().(type) {
+				case nil:
+					return // screen finalized
+				case *tcell.EventKey:
+					if ev.Key() == tcell.KeyCtrlC || ev.Key() == tcell.KeyEscape || ev.Rune() == 'q' {
+						cancel()
+						return
+					}
+				case *tcell.EventResize:
+					screen.Sync()
+				}
+			}
+		}()
+
+		d := &dashboard{screen: screen}
+		d.draw()
+		t := time.NewTicker(watchInterval)
+		defer t.Stop()
+		var prev *Snapshot
+		for {
+			snap, err := collectOnce(ctx)
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			snap.applyRates(prev)
+			prev = &snap
+			d.record(snap)
+			d.draw()
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-t.C:
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", time.Second, "refresh interval")
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+var (
+	wsInterval     time.Duration
+	wsAllowOrigins []string
+)
+
+// Keepalive timing: a ping goes out every wsPingPeriod and the client has
+// wsPongWait to answer before the connection is considered dead.
+const (
+	wsWriteWait  = 10 * time.Second
+	wsPongWait   = 60 * time.Second
+	wsPingPeriod = wsPongWait * 9 / 10
+)
+
+var wsUpgrader = websocket.Upgrader{CheckOrigin: wsCheckOrigin}
+
+// wsCheckOrigin lets a browser page open /ws only when it was served from
+// this same host or from one of the --ws-allow-origin origins; "*" allows
+// any. Clients that send no Origin, such as websocat or a script, aren't
+// browsers and are always let in.
+func wsCheckOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if u.Host != "" && strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	for _, allowed := range wsAllowOrigins {
+		if allowed == "*" || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	slog.Debug("websocket origin refused", "origin", origin, "remote", r.RemoteAddr)
+	return false
+}
+
+// wsHandler streams JSON snapshots to a WebSocket client every ?interval=
+// (a Go duration, default and minimum --ws-interval). All clients share the
+// server's one collection loop; a client with a longer interval is sent
+// only the snapshots that fall due on its schedule.
+func wsHandler(h *hub) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		every := wsInterval
+		if q := r.URL.Query().Get("interval"); q != "" {
+			d, err := time.ParseDuration(q)
+			if err != nil || d <= 0 {
+				http.Error(w, "invalid interval "+q, http.StatusBadRequest)
+				return
+			}
+			every = max(d, wsInterval)
+		}
+
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return // Upgrade has already replied
+		}
+		defer conn.Close()
+		slog.Debug("websocket client connected", "remote", r.RemoteAddr, "interval", every)
+
+		// The read side only exists to handle pongs and notice the client
+		// closing; anything it sends is discarded.
+		gone := make(chan struct{})
+		conn.SetReadDeadline(time.Now().Add(wsPongWait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+		})
+		go func() {
+			defer close(gone)
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}()
+
+		snaps, unsubscribe := h.subscribe()
+		defer unsubscribe()
+		ping := time.NewTicker(wsPingPeriod)
+		defer ping.Stop()
+
+		// Snapshots arrive every --ws-interval give or take collection time,
+		// so allow half a tick of slack when deciding one is due.
+		var lastSent time.Time
+		due := every - wsInterval/2
+		for {
+			var err error
+			select {
+			case <-gone:
+				slog.Debug("websocket client disconnected", "remote", r.RemoteAddr)
+				return
+			case s, ok := <-snaps:
+				if !ok {
+					msg := websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down")
+					conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(wsWriteWait))
+					return
+				}
+				if time.Since(lastSent) < due {
+					continue
+				}
+				var b []byte
+				if b, err = json.Marshal(s); err == nil {
+					conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+					err = conn.WriteMessage(websocket.TextMessage, b)
+					lastSent = time.Now()
+				}
+			case <-ping.C:
+				err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait))
+			}
+			if err != nil {
+				slog.Debug("websocket write failed", "remote", r.RemoteAddr, "err", err)
+				return
+			}
+		}
+	}
+}
+
+func init() {
+	serveCmd.Flags().DurationVar(&wsInterval, "ws-interval", time.Second, "how often the shared /ws collection runs; also the shortest interval a client may ask for")
+	serveCmd.Flags().StringSliceVar(&wsAllowOrigins, "ws-allow-origin", nil, "browser origins besides this server's own that may open /ws, e.g. https://dash.example.com (* for any); other cross-origin pages get 403")
+}
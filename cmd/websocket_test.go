@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestWSCheckOrigin(t *testing.T) {
+	old := wsAllowOrigins
+	t.Cleanup(func() { wsAllowOrigins = old })
+	wsAllowOrigins = []string{"https://dash.example.com", "http://localhost:3000/"}
+
+	for origin, want := range map[string]bool{
+		"":                              true, // not a browser
+		"http://stats-1:9100":           true, // the page /ws is served next to
+		"https://dash.example.com":      true,
+		"https://DASH.example.com":      true,
+		"http://localhost:3000":         true,
+		"http://dash.example.com":       false, // scheme matters
+		"https://dash.example.com:8443": false,
+		"https://evil.example.net":      false,
+		"null":                          false, // sandboxed iframes and file:// pages
+	} {
+		r := httptest.NewRequest(http.MethodGet, "http://stats-1:9100/ws", nil)
+		if origin != "" {
+			r.Header.Set("Origin", origin)
+		}
+		if got := wsCheckOrigin(r); got != want {
+			t.Errorf("Origin %q allowed = %v, want %v", origin, got, want)
+		}
+	}
+
+	wsAllowOrigins = []string{"*"}
+	r := httptest.NewRequest(http.MethodGet, "http://stats-1:9100/ws", nil)
+	r.Header.Set("Origin", "https://evil.example.net")
+	if !wsCheckOrigin(r) {
+		t.Error("--ws-allow-origin '*' refused an origin")
+	}
+}
+
+func TestWSHandlerRefusesForeignOrigin(t *testing.T) {
+	old := wsAllowOrigins
+	t.Cleanup(func() { wsAllowOrigins = old })
+	wsAllowOrigins = nil
+
+	srv := httptest.NewServer(wsHandler(newHub()))
+	defer srv.Close()
+	url := "ws" + strings.TrimPrefix(srv.URL, "http")
+
+	_, resp, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": {"https://dash.example.com"}})
+	if err == nil || resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("cross-origin dial: err %v, response %v; want 403", err, resp)
+	}
+
+	wsAllowOrigins = []string{"https://dash.example.com"}
+	conn, _, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": {"https://dash.example.com"}})
+	if err != nil {
+		t.Fatalf("dial from an allowed origin: %v", err)
+	}
+	conn.Close()
+}
@@ -0,0 +1,5 @@
+// Package gostatspb holds the protobuf and gRPC types served by
+// `gostats grpc-serve`. Regenerate after editing gostats.proto.
+package gostatspb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative gostats.proto
@@ -0,0 +1,1869 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: gostats.proto
+
+// Package gostats.v1 carries a fixed subset of the JSON snapshot written by
+// `gostats collect`: the fields defined below, under the same names as their
+// JSON keys. The --units display strings and the deprecated mem_free_pct are
+// left out, as is anything added to the JSON snapshot that has no field here;
+// use `collect --json` when you need the full snapshot. Optional fields are
+// only set when the corresponding metric was collected.
+
+package gostatspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type StreamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamRequest) Reset() {
+	*x = StreamRequest{}
+	mi := &file_gostats_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamRequest) ProtoMessage() {}
+
+func (x *StreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gostats_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamRequest.ProtoReflect.Descriptor instead.
+func (*StreamRequest) Descriptor() ([]byte, []int) {
+	return file_gostats_proto_rawDescGZIP(), []int{0}
+}
+
+type Snapshot struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Ts                *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=ts,proto3" json:"ts,omitempty"`
+	Host              string                 `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	Os                string                 `protobuf:"bytes,3,opt,name=os,proto3" json:"os,omitempty"`
+	UptimeSec         uint64                 `protobuf:"varint,4,opt,name=uptime_sec,json=uptimeSec,proto3" json:"uptime_sec,omitempty"`
+	KernelVersion     string                 `protobuf:"bytes,5,opt,name=kernel_version,json=kernelVersion,proto3" json:"kernel_version,omitempty"`
+	BootTime          string                 `protobuf:"bytes,6,opt,name=boot_time,json=bootTime,proto3" json:"boot_time,omitempty"`
+	Cloud             *CloudMeta             `protobuf:"bytes,7,opt,name=cloud,proto3" json:"cloud,omitempty"`
+	Labels            map[string]string      `protobuf:"bytes,8,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	CpuPercent        *float64               `protobuf:"fixed64,10,opt,name=cpu_percent,json=cpuPercent,proto3,oneof" json:"cpu_percent,omitempty"`
+	CpuPerCore        []float64              `protobuf:"fixed64,11,rep,packed,name=cpu_per_core,json=cpuPerCore,proto3" json:"cpu_per_core,omitempty"`
+	CpuTimes          *CPUTimes              `protobuf:"bytes,12,opt,name=cpu_times,json=cpuTimes,proto3" json:"cpu_times,omitempty"`
+	Load1             *float64               `protobuf:"fixed64,13,opt,name=load1,proto3,oneof" json:"load1,omitempty"`
+	Load5             *float64               `protobuf:"fixed64,14,opt,name=load5,proto3,oneof" json:"load5,omitempty"`
+	Load15            *float64               `protobuf:"fixed64,15,opt,name=load15,proto3,oneof" json:"load15,omitempty"`
+	LoadPerCore       *float64               `protobuf:"fixed64,16,opt,name=load_per_core,json=loadPerCore,proto3,oneof" json:"load_per_core,omitempty"`
+	MemUsedMb         *uint64                `protobuf:"varint,20,opt,name=mem_used_mb,json=memUsedMb,proto3,oneof" json:"mem_used_mb,omitempty"`
+	MemTotalMb        *uint64                `protobuf:"varint,21,opt,name=mem_total_mb,json=memTotalMb,proto3,oneof" json:"mem_total_mb,omitempty"`
+	MemUsedPct        *float64               `protobuf:"fixed64,22,opt,name=mem_used_pct,json=memUsedPct,proto3,oneof" json:"mem_used_pct,omitempty"`
+	MemAvailableMb    *uint64                `protobuf:"varint,23,opt,name=mem_available_mb,json=memAvailableMb,proto3,oneof" json:"mem_available_mb,omitempty"`
+	MemCachedMb       *uint64                `protobuf:"varint,24,opt,name=mem_cached_mb,json=memCachedMb,proto3,oneof" json:"mem_cached_mb,omitempty"`
+	MemBuffersMb      *uint64                `protobuf:"varint,25,opt,name=mem_buffers_mb,json=memBuffersMb,proto3,oneof" json:"mem_buffers_mb,omitempty"`
+	SwapUsedMb        *uint64                `protobuf:"varint,26,opt,name=swap_used_mb,json=swapUsedMb,proto3,oneof" json:"swap_used_mb,omitempty"`
+	SwapTotalMb       *uint64                `protobuf:"varint,27,opt,name=swap_total_mb,json=swapTotalMb,proto3,oneof" json:"swap_total_mb,omitempty"`
+	SwapUsedPct       *float64               `protobuf:"fixed64,28,opt,name=swap_used_pct,json=swapUsedPct,proto3,oneof" json:"swap_used_pct,omitempty"`
+	Disks             []*DiskStat            `protobuf:"bytes,30,rep,name=disks,proto3" json:"disks,omitempty"`
+	DiskIo            []*DiskIOStat          `protobuf:"bytes,31,rep,name=disk_io,json=diskIo,proto3" json:"disk_io,omitempty"`
+	NetBytesIn        *uint64                `protobuf:"varint,40,opt,name=net_bytes_in,json=netBytesIn,proto3,oneof" json:"net_bytes_in,omitempty"`
+	NetBytesOut       *uint64                `protobuf:"varint,41,opt,name=net_bytes_out,json=netBytesOut,proto3,oneof" json:"net_bytes_out,omitempty"`
+	NetRateInBps      *float64               `protobuf:"fixed64,42,opt,name=net_rate_in_bps,json=netRateInBps,proto3,oneof" json:"net_rate_in_bps,omitempty"`
+	NetRateOutBps     *float64               `protobuf:"fixed64,43,opt,name=net_rate_out_bps,json=netRateOutBps,proto3,oneof" json:"net_rate_out_bps,omitempty"`
+	NetErrIn          *uint64                `protobuf:"varint,44,opt,name=net_err_in,json=netErrIn,proto3,oneof" json:"net_err_in,omitempty"`
+	NetErrOut         *uint64                `protobuf:"varint,45,opt,name=net_err_out,json=netErrOut,proto3,oneof" json:"net_err_out,omitempty"`
+	NetDropIn         *uint64                `protobuf:"varint,46,opt,name=net_drop_in,json=netDropIn,proto3,oneof" json:"net_drop_in,omitempty"`
+	NetDropOut        *uint64                `protobuf:"varint,47,opt,name=net_drop_out,json=netDropOut,proto3,oneof" json:"net_drop_out,omitempty"`
+	NetInterfaces     []*NetStat             `protobuf:"bytes,48,rep,name=net_interfaces,json=netInterfaces,proto3" json:"net_interfaces,omitempty"`
+	TcpStates         map[string]int64       `protobuf:"bytes,49,rep,name=tcp_states,json=tcpStates,proto3" json:"tcp_states,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	NetErrInPerSec    *float64               `protobuf:"fixed64,72,opt,name=net_err_in_per_sec,json=netErrInPerSec,proto3,oneof" json:"net_err_in_per_sec,omitempty"`
+	NetErrOutPerSec   *float64               `protobuf:"fixed64,73,opt,name=net_err_out_per_sec,json=netErrOutPerSec,proto3,oneof" json:"net_err_out_per_sec,omitempty"`
+	NetDropInPerSec   *float64               `protobuf:"fixed64,74,opt,name=net_drop_in_per_sec,json=netDropInPerSec,proto3,oneof" json:"net_drop_in_per_sec,omitempty"`
+	NetDropOutPerSec  *float64               `protobuf:"fixed64,75,opt,name=net_drop_out_per_sec,json=netDropOutPerSec,proto3,oneof" json:"net_drop_out_per_sec,omitempty"`
+	Ports             *PortSummary           `protobuf:"bytes,76,opt,name=ports,proto3" json:"ports,omitempty"`
+	CtxSwitches       *uint64                `protobuf:"varint,50,opt,name=ctx_switches,json=ctxSwitches,proto3,oneof" json:"ctx_switches,omitempty"`
+	Interrupts        *uint64                `protobuf:"varint,51,opt,name=interrupts,proto3,oneof" json:"interrupts,omitempty"`
+	CtxSwitchesPerSec *float64               `protobuf:"fixed64,52,opt,name=ctx_switches_per_sec,json=ctxSwitchesPerSec,proto3,oneof" json:"ctx_switches_per_sec,omitempty"`
+	InterruptsPerSec  *float64               `protobuf:"fixed64,53,opt,name=interrupts_per_sec,json=interruptsPerSec,proto3,oneof" json:"interrupts_per_sec,omitempty"`
+	FdsOpen           *uint64                `protobuf:"varint,54,opt,name=fds_open,json=fdsOpen,proto3,oneof" json:"fds_open,omitempty"`
+	FdsMax            *uint64                `protobuf:"varint,55,opt,name=fds_max,json=fdsMax,proto3,oneof" json:"fds_max,omitempty"`
+	FdsUsedPct        *float64               `protobuf:"fixed64,56,opt,name=fds_used_pct,json=fdsUsedPct,proto3,oneof" json:"fds_used_pct,omitempty"`
+	Cgroup            *CgroupStat            `protobuf:"bytes,60,opt,name=cgroup,proto3" json:"cgroup,omitempty"`
+	Smoothed          *Smoothed              `protobuf:"bytes,77,opt,name=smoothed,proto3" json:"smoothed,omitempty"`
+	UserCount         *uint64                `protobuf:"varint,61,opt,name=user_count,json=userCount,proto3,oneof" json:"user_count,omitempty"`
+	Users             []*UserSession         `protobuf:"bytes,78,rep,name=users,proto3" json:"users,omitempty"`
+	ProcCount         *uint64                `protobuf:"varint,62,opt,name=proc_count,json=procCount,proto3,oneof" json:"proc_count,omitempty"`
+	ThreadCount       *uint64                `protobuf:"varint,63,opt,name=thread_count,json=threadCount,proto3,oneof" json:"thread_count,omitempty"`
+	ZombieCount       *uint64                `protobuf:"varint,64,opt,name=zombie_count,json=zombieCount,proto3,oneof" json:"zombie_count,omitempty"`
+	Temperatures      []*SensorTemp          `protobuf:"bytes,65,rep,name=temperatures,proto3" json:"temperatures,omitempty"`
+	BatteryPercent    *float64               `protobuf:"fixed64,66,opt,name=battery_percent,json=batteryPercent,proto3,oneof" json:"battery_percent,omitempty"`
+	BatteryCharging   *bool                  `protobuf:"varint,67,opt,name=battery_charging,json=batteryCharging,proto3,oneof" json:"battery_charging,omitempty"`
+	Processes         []*ProcStat            `protobuf:"bytes,68,rep,name=processes,proto3" json:"processes,omitempty"`
+	Errors            map[string]string      `protobuf:"bytes,70,rep,name=errors,proto3" json:"errors,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Snapshot) Reset() {
+	*x = Snapshot{}
+	mi := &file_gostats_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snapshot) ProtoMessage() {}
+
+func (x *Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_gostats_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snapshot.ProtoReflect.Descriptor instead.
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return file_gostats_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Snapshot) GetTs() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Ts
+	}
+	return nil
+}
+
+func (x *Snapshot) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *Snapshot) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *Snapshot) GetUptimeSec() uint64 {
+	if x != nil {
+		return x.UptimeSec
+	}
+	return 0
+}
+
+func (x *Snapshot) GetKernelVersion() string {
+	if x != nil {
+		return x.KernelVersion
+	}
+	return ""
+}
+
+func (x *Snapshot) GetBootTime() string {
+	if x != nil {
+		return x.BootTime
+	}
+	return ""
+}
+
+func (x *Snapshot) GetCloud() *CloudMeta {
+	if x != nil {
+		return x.Cloud
+	}
+	return nil
+}
+
+func (x *Snapshot) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Snapshot) GetCpuPercent() float64 {
+	if x != nil && x.CpuPercent != nil {
+		return *x.CpuPercent
+	}
+	return 0
+}
+
+func (x *Snapshot) GetCpuPerCore() []float64 {
+	if x != nil {
+		return x.CpuPerCore
+	}
+	return nil
+}
+
+func (x *Snapshot) GetCpuTimes() *CPUTimes {
+	if x != nil {
+		return x.CpuTimes
+	}
+	return nil
+}
+
+func (x *Snapshot) GetLoad1() float64 {
+	if x != nil && x.Load1 != nil {
+		return *x.Load1
+	}
+	return 0
+}
+
+func (x *Snapshot) GetLoad5() float64 {
+	if x != nil && x.Load5 != nil {
+		return *x.Load5
+	}
+	return 0
+}
+
+func (x *Snapshot) GetLoad15() float64 {
+	if x != nil && x.Load15 != nil {
+		return *x.Load15
+	}
+	return 0
+}
+
+func (x *Snapshot) GetLoadPerCore() float64 {
+	if x != nil && x.LoadPerCore != nil {
+		return *x.LoadPerCore
+	}
+	return 0
+}
+
+func (x *Snapshot) GetMemUsedMb() uint64 {
+	if x != nil && x.MemUsedMb != nil {
+		return *x.MemUsedMb
+	}
+	return 0
+}
+
+func (x *Snapshot) GetMemTotalMb() uint64 {
+	if x != nil && x.MemTotalMb != nil {
+		return *x.MemTotalMb
+	}
+	return 0
+}
+
+func (x *Snapshot) GetMemUsedPct() float64 {
+	if x != nil && x.MemUsedPct != nil {
+		return *x.MemUsedPct
+	}
+	return 0
+}
+
+func (x *Snapshot) GetMemAvailableMb() uint64 {
+	if x != nil && x.MemAvailableMb != nil {
+		return *x.MemAvailableMb
+	}
+	return 0
+}
+
+func (x *Snapshot) GetMemCachedMb() uint64 {
+	if x != nil && x.MemCachedMb != nil {
+		return *x.MemCachedMb
+	}
+	return 0
+}
+
+func (x *Snapshot) GetMemBuffersMb() uint64 {
+	if x != nil && x.MemBuffersMb != nil {
+		return *x.MemBuffersMb
+	}
+	return 0
+}
+
+func (x *Snapshot) GetSwapUsedMb() uint64 {
+	if x != nil && x.SwapUsedMb != nil {
+		return *x.SwapUsedMb
+	}
+	return 0
+}
+
+func (x *Snapshot) GetSwapTotalMb() uint64 {
+	if x != nil && x.SwapTotalMb != nil {
+		return *x.SwapTotalMb
+	}
+	return 0
+}
+
+func (x *Snapshot) GetSwapUsedPct() float64 {
+	if x != nil && x.SwapUsedPct != nil {
+		return *x.SwapUsedPct
+	}
+	return 0
+}
+
+func (x *Snapshot) GetDisks() []*DiskStat {
+	if x != nil {
+		return x.Disks
+	}
+	return nil
+}
+
+func (x *Snapshot) GetDiskIo() []*DiskIOStat {
+	if x != nil {
+		return x.DiskIo
+	}
+	return nil
+}
+
+func (x *Snapshot) GetNetBytesIn() uint64 {
+	if x != nil && x.NetBytesIn != nil {
+		return *x.NetBytesIn
+	}
+	return 0
+}
+
+func (x *Snapshot) GetNetBytesOut() uint64 {
+	if x != nil && x.NetBytesOut != nil {
+		return *x.NetBytesOut
+	}
+	return 0
+}
+
+func (x *Snapshot) GetNetRateInBps() float64 {
+	if x != nil && x.NetRateInBps != nil {
+		return *x.NetRateInBps
+	}
+	return 0
+}
+
+func (x *Snapshot) GetNetRateOutBps() float64 {
+	if x != nil && x.NetRateOutBps != nil {
+		return *x.NetRateOutBps
+	}
+	return 0
+}
+
+func (x *Snapshot) GetNetErrIn() uint64 {
+	if x != nil && x.NetErrIn != nil {
+		return *x.NetErrIn
+	}
+	return 0
+}
+
+func (x *Snapshot) GetNetErrOut() uint64 {
+	if x != nil && x.NetErrOut != nil {
+		return *x.NetErrOut
+	}
+	return 0
+}
+
+func (x *Snapshot) GetNetDropIn() uint64 {
+	if x != nil && x.NetDropIn != nil {
+		return *x.NetDropIn
+	}
+	return 0
+}
+
+func (x *Snapshot) GetNetDropOut() uint64 {
+	if x != nil && x.NetDropOut != nil {
+		return *x.NetDropOut
+	}
+	return 0
+}
+
+func (x *Snapshot) GetNetInterfaces() []*NetStat {
+	if x != nil {
+		return x.NetInterfaces
+	}
+	return nil
+}
+
+func (x *Snapshot) GetTcpStates() map[string]int64 {
+	if x != nil {
+		return x.TcpStates
+	}
+	return nil
+}
+
+func (x *Snapshot) GetNetErrInPerSec() float64 {
+	if x != nil && x.NetErrInPerSec != nil {
+		return *x.NetErrInPerSec
+	}
+	return 0
+}
+
+func (x *Snapshot) GetNetErrOutPerSec() float64 {
+	if x != nil && x.NetErrOutPerSec != nil {
+		return *x.NetErrOutPerSec
+	}
+	return 0
+}
+
+func (x *Snapshot) GetNetDropInPerSec() float64 {
+	if x != nil && x.NetDropInPerSec != nil {
+		return *x.NetDropInPerSec
+	}
+	return 0
+}
+
+func (x *Snapshot) GetNetDropOutPerSec() float64 {
+	if x != nil && x.NetDropOutPerSec != nil {
+		return *x.NetDropOutPerSec
+	}
+	return 0
+}
+
+func (x *Snapshot) GetPorts() *PortSummary {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+func (x *Snapshot) GetCtxSwitches() uint64 {
+	if x != nil && x.CtxSwitches != nil {
+		return *x.CtxSwitches
+	}
+	return 0
+}
+
+func (x *Snapshot) GetInterrupts() uint64 {
+	if x != nil && x.Interrupts != nil {
+		return *x.Interrupts
+	}
+	return 0
+}
+
+func (x *Snapshot) GetCtxSwitchesPerSec() float64 {
+	if x != nil && x.CtxSwitchesPerSec != nil {
+		return *x.CtxSwitchesPerSec
+	}
+	return 0
+}
+
+func (x *Snapshot) GetInterruptsPerSec() float64 {
+	if x != nil && x.InterruptsPerSec != nil {
+		return *x.InterruptsPerSec
+	}
+	return 0
+}
+
+func (x *Snapshot) GetFdsOpen() uint64 {
+	if x != nil && x.FdsOpen != nil {
+		return *x.FdsOpen
+	}
+	return 0
+}
+
+func (x *Snapshot) GetFdsMax() uint64 {
+	if x != nil && x.FdsMax != nil {
+		return *x.FdsMax
+	}
+	return 0
+}
+
+func (x *Snapshot) GetFdsUsedPct() float64 {
+	if x != nil && x.FdsUsedPct != nil {
+		return *x.FdsUsedPct
+	}
+	return 0
+}
+
+func (x *Snapshot) GetCgroup() *CgroupStat {
+	if x != nil {
+		return x.Cgroup
+	}
+	return nil
+}
+
+func (x *Snapshot) GetSmoothed() *Smoothed {
+	if x != nil {
+		return x.Smoothed
+	}
+	return nil
+}
+
+func (x *Snapshot) GetUserCount() uint64 {
+	if x != nil && x.UserCount != nil {
+		return *x.UserCount
+	}
+	return 0
+}
+
+func (x *Snapshot) GetUsers() []*UserSession {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *Snapshot) GetProcCount() uint64 {
+	if x != nil && x.ProcCount != nil {
+		return *x.ProcCount
+	}
+	return 0
+}
+
+func (x *Snapshot) GetThreadCount() uint64 {
+	if x != nil && x.ThreadCount != nil {
+		return *x.ThreadCount
+	}
+	return 0
+}
+
+func (x *Snapshot) GetZombieCount() uint64 {
+	if x != nil && x.ZombieCount != nil {
+		return *x.ZombieCount
+	}
+	return 0
+}
+
+func (x *Snapshot) GetTemperatures() []*SensorTemp {
+	if x != nil {
+		return x.Temperatures
+	}
+	return nil
+}
+
+func (x *Snapshot) GetBatteryPercent() float64 {
+	if x != nil && x.BatteryPercent != nil {
+		return *x.BatteryPercent
+	}
+	return 0
+}
+
+func (x *Snapshot) GetBatteryCharging() bool {
+	if x != nil && x.BatteryCharging != nil {
+		return *x.BatteryCharging
+	}
+	return false
+}
+
+func (x *Snapshot) GetProcesses() []*ProcStat {
+	if x != nil {
+		return x.Processes
+	}
+	return nil
+}
+
+func (x *Snapshot) GetErrors() map[string]string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type CloudMeta struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	InstanceId    string                 `protobuf:"bytes,2,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	Region        string                 `protobuf:"bytes,3,opt,name=region,proto3" json:"region,omitempty"`
+	Zone          string                 `protobuf:"bytes,4,opt,name=zone,proto3" json:"zone,omitempty"`
+	InstanceType  string                 `protobuf:"bytes,5,opt,name=instance_type,json=instanceType,proto3" json:"instance_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloudMeta) Reset() {
+	*x = CloudMeta{}
+	mi := &file_gostats_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloudMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloudMeta) ProtoMessage() {}
+
+func (x *CloudMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_gostats_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloudMeta.ProtoReflect.Descriptor instead.
+func (*CloudMeta) Descriptor() ([]byte, []int) {
+	return file_gostats_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CloudMeta) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *CloudMeta) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *CloudMeta) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *CloudMeta) GetZone() string {
+	if x != nil {
+		return x.Zone
+	}
+	return ""
+}
+
+func (x *CloudMeta) GetInstanceType() string {
+	if x != nil {
+		return x.InstanceType
+	}
+	return ""
+}
+
+type CPUTimes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          float64                `protobuf:"fixed64,1,opt,name=user,proto3" json:"user,omitempty"`
+	System        float64                `protobuf:"fixed64,2,opt,name=system,proto3" json:"system,omitempty"`
+	Idle          float64                `protobuf:"fixed64,3,opt,name=idle,proto3" json:"idle,omitempty"`
+	Iowait        *float64               `protobuf:"fixed64,4,opt,name=iowait,proto3,oneof" json:"iowait,omitempty"`
+	Irq           *float64               `protobuf:"fixed64,5,opt,name=irq,proto3,oneof" json:"irq,omitempty"`
+	Softirq       *float64               `protobuf:"fixed64,6,opt,name=softirq,proto3,oneof" json:"softirq,omitempty"`
+	Steal         *float64               `protobuf:"fixed64,7,opt,name=steal,proto3,oneof" json:"steal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CPUTimes) Reset() {
+	*x = CPUTimes{}
+	mi := &file_gostats_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CPUTimes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CPUTimes) ProtoMessage() {}
+
+func (x *CPUTimes) ProtoReflect() protoreflect.Message {
+	mi := &file_gostats_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CPUTimes.ProtoReflect.Descriptor instead.
+func (*CPUTimes) Descriptor() ([]byte, []int) {
+	return file_gostats_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CPUTimes) GetUser() float64 {
+	if x != nil {
+		return x.User
+	}
+	return 0
+}
+
+func (x *CPUTimes) GetSystem() float64 {
+	if x != nil {
+		return x.System
+	}
+	return 0
+}
+
+func (x *CPUTimes) GetIdle() float64 {
+	if x != nil {
+		return x.Idle
+	}
+	return 0
+}
+
+func (x *CPUTimes) GetIowait() float64 {
+	if x != nil && x.Iowait != nil {
+		return *x.Iowait
+	}
+	return 0
+}
+
+func (x *CPUTimes) GetIrq() float64 {
+	if x != nil && x.Irq != nil {
+		return *x.Irq
+	}
+	return 0
+}
+
+func (x *CPUTimes) GetSoftirq() float64 {
+	if x != nil && x.Softirq != nil {
+		return *x.Softirq
+	}
+	return 0
+}
+
+func (x *CPUTimes) GetSteal() float64 {
+	if x != nil && x.Steal != nil {
+		return *x.Steal
+	}
+	return 0
+}
+
+type DiskStat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	UsedGb        float64                `protobuf:"fixed64,2,opt,name=used_gb,json=usedGb,proto3" json:"used_gb,omitempty"`
+	TotalGb       float64                `protobuf:"fixed64,3,opt,name=total_gb,json=totalGb,proto3" json:"total_gb,omitempty"`
+	UsedPct       float64                `protobuf:"fixed64,4,opt,name=used_pct,json=usedPct,proto3" json:"used_pct,omitempty"`
+	InodesUsed    uint64                 `protobuf:"varint,5,opt,name=inodes_used,json=inodesUsed,proto3" json:"inodes_used,omitempty"`
+	InodesTotal   uint64                 `protobuf:"varint,6,opt,name=inodes_total,json=inodesTotal,proto3" json:"inodes_total,omitempty"`
+	InodesUsedPct *float64               `protobuf:"fixed64,7,opt,name=inodes_used_pct,json=inodesUsedPct,proto3,oneof" json:"inodes_used_pct,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiskStat) Reset() {
+	*x = DiskStat{}
+	mi := &file_gostats_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiskStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiskStat) ProtoMessage() {}
+
+func (x *DiskStat) ProtoReflect() protoreflect.Message {
+	mi := &file_gostats_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiskStat.ProtoReflect.Descriptor instead.
+func (*DiskStat) Descriptor() ([]byte, []int) {
+	return file_gostats_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DiskStat) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *DiskStat) GetUsedGb() float64 {
+	if x != nil {
+		return x.UsedGb
+	}
+	return 0
+}
+
+func (x *DiskStat) GetTotalGb() float64 {
+	if x != nil {
+		return x.TotalGb
+	}
+	return 0
+}
+
+func (x *DiskStat) GetUsedPct() float64 {
+	if x != nil {
+		return x.UsedPct
+	}
+	return 0
+}
+
+func (x *DiskStat) GetInodesUsed() uint64 {
+	if x != nil {
+		return x.InodesUsed
+	}
+	return 0
+}
+
+func (x *DiskStat) GetInodesTotal() uint64 {
+	if x != nil {
+		return x.InodesTotal
+	}
+	return 0
+}
+
+func (x *DiskStat) GetInodesUsedPct() float64 {
+	if x != nil && x.InodesUsedPct != nil {
+		return *x.InodesUsedPct
+	}
+	return 0
+}
+
+type DiskIOStat struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ReadBytes      uint64                 `protobuf:"varint,2,opt,name=read_bytes,json=readBytes,proto3" json:"read_bytes,omitempty"`
+	WriteBytes     uint64                 `protobuf:"varint,3,opt,name=write_bytes,json=writeBytes,proto3" json:"write_bytes,omitempty"`
+	ReadCount      uint64                 `protobuf:"varint,4,opt,name=read_count,json=readCount,proto3" json:"read_count,omitempty"`
+	WriteCount     uint64                 `protobuf:"varint,5,opt,name=write_count,json=writeCount,proto3" json:"write_count,omitempty"`
+	ReadBps        *float64               `protobuf:"fixed64,6,opt,name=read_bps,json=readBps,proto3,oneof" json:"read_bps,omitempty"`
+	WriteBps       *float64               `protobuf:"fixed64,7,opt,name=write_bps,json=writeBps,proto3,oneof" json:"write_bps,omitempty"`
+	ReadOpsPerSec  *float64               `protobuf:"fixed64,8,opt,name=read_ops_per_sec,json=readOpsPerSec,proto3,oneof" json:"read_ops_per_sec,omitempty"`
+	WriteOpsPerSec *float64               `protobuf:"fixed64,9,opt,name=write_ops_per_sec,json=writeOpsPerSec,proto3,oneof" json:"write_ops_per_sec,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DiskIOStat) Reset() {
+	*x = DiskIOStat{}
+	mi := &file_gostats_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiskIOStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiskIOStat) ProtoMessage() {}
+
+func (x *DiskIOStat) ProtoReflect() protoreflect.Message {
+	mi := &file_gostats_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiskIOStat.ProtoReflect.Descriptor instead.
+func (*DiskIOStat) Descriptor() ([]byte, []int) {
+	return file_gostats_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DiskIOStat) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DiskIOStat) GetReadBytes() uint64 {
+	if x != nil {
+		return x.ReadBytes
+	}
+	return 0
+}
+
+func (x *DiskIOStat) GetWriteBytes() uint64 {
+	if x != nil {
+		return x.WriteBytes
+	}
+	return 0
+}
+
+func (x *DiskIOStat) GetReadCount() uint64 {
+	if x != nil {
+		return x.ReadCount
+	}
+	return 0
+}
+
+func (x *DiskIOStat) GetWriteCount() uint64 {
+	if x != nil {
+		return x.WriteCount
+	}
+	return 0
+}
+
+func (x *DiskIOStat) GetReadBps() float64 {
+	if x != nil && x.ReadBps != nil {
+		return *x.ReadBps
+	}
+	return 0
+}
+
+func (x *DiskIOStat) GetWriteBps() float64 {
+	if x != nil && x.WriteBps != nil {
+		return *x.WriteBps
+	}
+	return 0
+}
+
+func (x *DiskIOStat) GetReadOpsPerSec() float64 {
+	if x != nil && x.ReadOpsPerSec != nil {
+		return *x.ReadOpsPerSec
+	}
+	return 0
+}
+
+func (x *DiskIOStat) GetWriteOpsPerSec() float64 {
+	if x != nil && x.WriteOpsPerSec != nil {
+		return *x.WriteOpsPerSec
+	}
+	return 0
+}
+
+type NetStat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	BytesIn       uint64                 `protobuf:"varint,2,opt,name=bytes_in,json=bytesIn,proto3" json:"bytes_in,omitempty"`
+	BytesOut      uint64                 `protobuf:"varint,3,opt,name=bytes_out,json=bytesOut,proto3" json:"bytes_out,omitempty"`
+	PacketsIn     uint64                 `protobuf:"varint,4,opt,name=packets_in,json=packetsIn,proto3" json:"packets_in,omitempty"`
+	PacketsOut    uint64                 `protobuf:"varint,5,opt,name=packets_out,json=packetsOut,proto3" json:"packets_out,omitempty"`
+	ErrIn         uint64                 `protobuf:"varint,6,opt,name=err_in,json=errIn,proto3" json:"err_in,omitempty"`
+	ErrOut        uint64                 `protobuf:"varint,7,opt,name=err_out,json=errOut,proto3" json:"err_out,omitempty"`
+	DropIn        uint64                 `protobuf:"varint,8,opt,name=drop_in,json=dropIn,proto3" json:"drop_in,omitempty"`
+	DropOut       uint64                 `protobuf:"varint,9,opt,name=drop_out,json=dropOut,proto3" json:"drop_out,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NetStat) Reset() {
+	*x = NetStat{}
+	mi := &file_gostats_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetStat) ProtoMessage() {}
+
+func (x *NetStat) ProtoReflect() protoreflect.Message {
+	mi := &file_gostats_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetStat.ProtoReflect.Descriptor instead.
+func (*NetStat) Descriptor() ([]byte, []int) {
+	return file_gostats_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *NetStat) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NetStat) GetBytesIn() uint64 {
+	if x != nil {
+		return x.BytesIn
+	}
+	return 0
+}
+
+func (x *NetStat) GetBytesOut() uint64 {
+	if x != nil {
+		return x.BytesOut
+	}
+	return 0
+}
+
+func (x *NetStat) GetPacketsIn() uint64 {
+	if x != nil {
+		return x.PacketsIn
+	}
+	return 0
+}
+
+func (x *NetStat) GetPacketsOut() uint64 {
+	if x != nil {
+		return x.PacketsOut
+	}
+	return 0
+}
+
+func (x *NetStat) GetErrIn() uint64 {
+	if x != nil {
+		return x.ErrIn
+	}
+	return 0
+}
+
+func (x *NetStat) GetErrOut() uint64 {
+	if x != nil {
+		return x.ErrOut
+	}
+	return 0
+}
+
+func (x *NetStat) GetDropIn() uint64 {
+	if x != nil {
+		return x.DropIn
+	}
+	return 0
+}
+
+func (x *NetStat) GetDropOut() uint64 {
+	if x != nil {
+		return x.DropOut
+	}
+	return 0
+}
+
+type CgroupStat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	MemLimitMb    *uint64                `protobuf:"varint,2,opt,name=mem_limit_mb,json=memLimitMb,proto3,oneof" json:"mem_limit_mb,omitempty"`
+	MemUsedMb     uint64                 `protobuf:"varint,3,opt,name=mem_used_mb,json=memUsedMb,proto3" json:"mem_used_mb,omitempty"`
+	MemUsedPct    *float64               `protobuf:"fixed64,4,opt,name=mem_used_pct,json=memUsedPct,proto3,oneof" json:"mem_used_pct,omitempty"`
+	CpuLimitCores *float64               `protobuf:"fixed64,5,opt,name=cpu_limit_cores,json=cpuLimitCores,proto3,oneof" json:"cpu_limit_cores,omitempty"`
+	CpuPercent    *float64               `protobuf:"fixed64,6,opt,name=cpu_percent,json=cpuPercent,proto3,oneof" json:"cpu_percent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CgroupStat) Reset() {
+	*x = CgroupStat{}
+	mi := &file_gostats_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CgroupStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CgroupStat) ProtoMessage() {}
+
+func (x *CgroupStat) ProtoReflect() protoreflect.Message {
+	mi := &file_gostats_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CgroupStat.ProtoReflect.Descriptor instead.
+func (*CgroupStat) Descriptor() ([]byte, []int) {
+	return file_gostats_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CgroupStat) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *CgroupStat) GetMemLimitMb() uint64 {
+	if x != nil && x.MemLimitMb != nil {
+		return *x.MemLimitMb
+	}
+	return 0
+}
+
+func (x *CgroupStat) GetMemUsedMb() uint64 {
+	if x != nil {
+		return x.MemUsedMb
+	}
+	return 0
+}
+
+func (x *CgroupStat) GetMemUsedPct() float64 {
+	if x != nil && x.MemUsedPct != nil {
+		return *x.MemUsedPct
+	}
+	return 0
+}
+
+func (x *CgroupStat) GetCpuLimitCores() float64 {
+	if x != nil && x.CpuLimitCores != nil {
+		return *x.CpuLimitCores
+	}
+	return 0
+}
+
+func (x *CgroupStat) GetCpuPercent() float64 {
+	if x != nil && x.CpuPercent != nil {
+		return *x.CpuPercent
+	}
+	return 0
+}
+
+type SensorTemp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Current       float64                `protobuf:"fixed64,2,opt,name=current,proto3" json:"current,omitempty"`
+	High          float64                `protobuf:"fixed64,3,opt,name=high,proto3" json:"high,omitempty"`
+	Critical      float64                `protobuf:"fixed64,4,opt,name=critical,proto3" json:"critical,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SensorTemp) Reset() {
+	*x = SensorTemp{}
+	mi := &file_gostats_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SensorTemp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SensorTemp) ProtoMessage() {}
+
+func (x *SensorTemp) ProtoReflect() protoreflect.Message {
+	mi := &file_gostats_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SensorTemp.ProtoReflect.Descriptor instead.
+func (*SensorTemp) Descriptor() ([]byte, []int) {
+	return file_gostats_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SensorTemp) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SensorTemp) GetCurrent() float64 {
+	if x != nil {
+		return x.Current
+	}
+	return 0
+}
+
+func (x *SensorTemp) GetHigh() float64 {
+	if x != nil {
+		return x.High
+	}
+	return 0
+}
+
+func (x *SensorTemp) GetCritical() float64 {
+	if x != nil {
+		return x.Critical
+	}
+	return 0
+}
+
+type PortSummary struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Listening           []*ListenSocket        `protobuf:"bytes,1,rep,name=listening,proto3" json:"listening,omitempty"`
+	EstablishedByPort   map[uint32]int64       `protobuf:"bytes,2,rep,name=established_by_port,json=establishedByPort,proto3" json:"established_by_port,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	EstablishedOutbound int64                  `protobuf:"varint,3,opt,name=established_outbound,json=establishedOutbound,proto3" json:"established_outbound,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *PortSummary) Reset() {
+	*x = PortSummary{}
+	mi := &file_gostats_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PortSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PortSummary) ProtoMessage() {}
+
+func (x *PortSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_gostats_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PortSummary.ProtoReflect.Descriptor instead.
+func (*PortSummary) Descriptor() ([]byte, []int) {
+	return file_gostats_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PortSummary) GetListening() []*ListenSocket {
+	if x != nil {
+		return x.Listening
+	}
+	return nil
+}
+
+func (x *PortSummary) GetEstablishedByPort() map[uint32]int64 {
+	if x != nil {
+		return x.EstablishedByPort
+	}
+	return nil
+}
+
+func (x *PortSummary) GetEstablishedOutbound() int64 {
+	if x != nil {
+		return x.EstablishedOutbound
+	}
+	return 0
+}
+
+type ListenSocket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Addr          string                 `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+	Port          uint32                 `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListenSocket) Reset() {
+	*x = ListenSocket{}
+	mi := &file_gostats_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListenSocket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListenSocket) ProtoMessage() {}
+
+func (x *ListenSocket) ProtoReflect() protoreflect.Message {
+	mi := &file_gostats_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListenSocket.ProtoReflect.Descriptor instead.
+func (*ListenSocket) Descriptor() ([]byte, []int) {
+	return file_gostats_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListenSocket) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *ListenSocket) GetPort() uint32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+type Smoothed struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Window        int32                  `protobuf:"varint,1,opt,name=window,proto3" json:"window,omitempty"`
+	CpuPercent    *float64               `protobuf:"fixed64,2,opt,name=cpu_percent,json=cpuPercent,proto3,oneof" json:"cpu_percent,omitempty"`
+	MemUsedPct    *float64               `protobuf:"fixed64,3,opt,name=mem_used_pct,json=memUsedPct,proto3,oneof" json:"mem_used_pct,omitempty"`
+	NetRateInBps  *float64               `protobuf:"fixed64,4,opt,name=net_rate_in_bps,json=netRateInBps,proto3,oneof" json:"net_rate_in_bps,omitempty"`
+	NetRateOutBps *float64               `protobuf:"fixed64,5,opt,name=net_rate_out_bps,json=netRateOutBps,proto3,oneof" json:"net_rate_out_bps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Smoothed) Reset() {
+	*x = Smoothed{}
+	mi := &file_gostats_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Smoothed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Smoothed) ProtoMessage() {}
+
+func (x *Smoothed) ProtoReflect() protoreflect.Message {
+	mi := &file_gostats_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Smoothed.ProtoReflect.Descriptor instead.
+func (*Smoothed) Descriptor() ([]byte, []int) {
+	return file_gostats_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Smoothed) GetWindow() int32 {
+	if x != nil {
+		return x.Window
+	}
+	return 0
+}
+
+func (x *Smoothed) GetCpuPercent() float64 {
+	if x != nil && x.CpuPercent != nil {
+		return *x.CpuPercent
+	}
+	return 0
+}
+
+func (x *Smoothed) GetMemUsedPct() float64 {
+	if x != nil && x.MemUsedPct != nil {
+		return *x.MemUsedPct
+	}
+	return 0
+}
+
+func (x *Smoothed) GetNetRateInBps() float64 {
+	if x != nil && x.NetRateInBps != nil {
+		return *x.NetRateInBps
+	}
+	return 0
+}
+
+func (x *Smoothed) GetNetRateOutBps() float64 {
+	if x != nil && x.NetRateOutBps != nil {
+		return *x.NetRateOutBps
+	}
+	return 0
+}
+
+type UserSession struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          string                 `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Terminal      string                 `protobuf:"bytes,2,opt,name=terminal,proto3" json:"terminal,omitempty"`
+	Host          string                 `protobuf:"bytes,3,opt,name=host,proto3" json:"host,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserSession) Reset() {
+	*x = UserSession{}
+	mi := &file_gostats_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserSession) ProtoMessage() {}
+
+func (x *UserSession) ProtoReflect() protoreflect.Message {
+	mi := &file_gostats_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserSession.ProtoReflect.Descriptor instead.
+func (*UserSession) Descriptor() ([]byte, []int) {
+	return file_gostats_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UserSession) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *UserSession) GetTerminal() string {
+	if x != nil {
+		return x.Terminal
+	}
+	return ""
+}
+
+func (x *UserSession) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+type ProcStat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pid           int32                  `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CpuPercent    float64                `protobuf:"fixed64,3,opt,name=cpu_percent,json=cpuPercent,proto3" json:"cpu_percent,omitempty"`
+	MemMb         float64                `protobuf:"fixed64,4,opt,name=mem_mb,json=memMb,proto3" json:"mem_mb,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProcStat) Reset() {
+	*x = ProcStat{}
+	mi := &file_gostats_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcStat) ProtoMessage() {}
+
+func (x *ProcStat) ProtoReflect() protoreflect.Message {
+	mi := &file_gostats_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcStat.ProtoReflect.Descriptor instead.
+func (*ProcStat) Descriptor() ([]byte, []int) {
+	return file_gostats_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ProcStat) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *ProcStat) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProcStat) GetCpuPercent() float64 {
+	if x != nil {
+		return x.CpuPercent
+	}
+	return 0
+}
+
+func (x *ProcStat) GetMemMb() float64 {
+	if x != nil {
+		return x.MemMb
+	}
+	return 0
+}
+
+var File_gostats_proto protoreflect.FileDescriptor
+
+const file_gostats_proto_rawDesc = "" +
+	"\n" +
+	"\rgostats.proto\x12\n" +
+	"gostats.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x0f\n" +
+	"\rStreamRequest\"\xd1\x1a\n" +
+	"\bSnapshot\x12*\n" +
+	"\x02ts\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x02ts\x12\x12\n" +
+	"\x04host\x18\x02 \x01(\tR\x04host\x12\x0e\n" +
+	"\x02os\x18\x03 \x01(\tR\x02os\x12\x1d\n" +
+	"\n" +
+	"uptime_sec\x18\x04 \x01(\x04R\tuptimeSec\x12%\n" +
+	"\x0ekernel_version\x18\x05 \x01(\tR\rkernelVersion\x12\x1b\n" +
+	"\tboot_time\x18\x06 \x01(\tR\bbootTime\x12+\n" +
+	"\x05cloud\x18\a \x01(\v2\x15.gostats.v1.CloudMetaR\x05cloud\x128\n" +
+	"\x06labels\x18\b \x03(\v2 .gostats.v1.Snapshot.LabelsEntryR\x06labels\x12$\n" +
+	"\vcpu_percent\x18\n" +
+	" \x01(\x01H\x00R\n" +
+	"cpuPercent\x88\x01\x01\x12 \n" +
+	"\fcpu_per_core\x18\v \x03(\x01R\n" +
+	"cpuPerCore\x121\n" +
+	"\tcpu_times\x18\f \x01(\v2\x14.gostats.v1.CPUTimesR\bcpuTimes\x12\x19\n" +
+	"\x05load1\x18\r \x01(\x01H\x01R\x05load1\x88\x01\x01\x12\x19\n" +
+	"\x05load5\x18\x0e \x01(\x01H\x02R\x05load5\x88\x01\x01\x12\x1b\n" +
+	"\x06load15\x18\x0f \x01(\x01H\x03R\x06load15\x88\x01\x01\x12'\n" +
+	"\rload_per_core\x18\x10 \x01(\x01H\x04R\vloadPerCore\x88\x01\x01\x12#\n" +
+	"\vmem_used_mb\x18\x14 \x01(\x04H\x05R\tmemUsedMb\x88\x01\x01\x12%\n" +
+	"\fmem_total_mb\x18\x15 \x01(\x04H\x06R\n" +
+	"memTotalMb\x88\x01\x01\x12%\n" +
+	"\fmem_used_pct\x18\x16 \x01(\x01H\aR\n" +
+	"memUsedPct\x88\x01\x01\x12-\n" +
+	"\x10mem_available_mb\x18\x17 \x01(\x04H\bR\x0ememAvailableMb\x88\x01\x01\x12'\n" +
+	"\rmem_cached_mb\x18\x18 \x01(\x04H\tR\vmemCachedMb\x88\x01\x01\x12)\n" +
+	"\x0emem_buffers_mb\x18\x19 \x01(\x04H\n" +
+	"R\fmemBuffersMb\x88\x01\x01\x12%\n" +
+	"\fswap_used_mb\x18\x1a \x01(\x04H\vR\n" +
+	"swapUsedMb\x88\x01\x01\x12'\n" +
+	"\rswap_total_mb\x18\x1b \x01(\x04H\fR\vswapTotalMb\x88\x01\x01\x12'\n" +
+	"\rswap_used_pct\x18\x1c \x01(\x01H\rR\vswapUsedPct\x88\x01\x01\x12*\n" +
+	"\x05disks\x18\x1e \x03(\v2\x14.gostats.v1.DiskStatR\x05disks\x12/\n" +
+	"\adisk_io\x18\x1f \x03(\v2\x16.gostats.v1.DiskIOStatR\x06diskIo\x12%\n" +
+	"\fnet_bytes_in\x18( \x01(\x04H\x0eR\n" +
+	"netBytesIn\x88\x01\x01\x12'\n" +
+	"\rnet_bytes_out\x18) \x01(\x04H\x0fR\vnetBytesOut\x88\x01\x01\x12*\n" +
+	"\x0fnet_rate_in_bps\x18* \x01(\x01H\x10R\fnetRateInBps\x88\x01\x01\x12,\n" +
+	"\x10net_rate_out_bps\x18+ \x01(\x01H\x11R\rnetRateOutBps\x88\x01\x01\x12!\n" +
+	"\n" +
+	"net_err_in\x18, \x01(\x04H\x12R\bnetErrIn\x88\x01\x01\x12#\n" +
+	"\vnet_err_out\x18- \x01(\x04H\x13R\tnetErrOut\x88\x01\x01\x12#\n" +
+	"\vnet_drop_in\x18. \x01(\x04H\x14R\tnetDropIn\x88\x01\x01\x12%\n" +
+	"\fnet_drop_out\x18/ \x01(\x04H\x15R\n" +
+	"netDropOut\x88\x01\x01\x12:\n" +
+	"\x0enet_interfaces\x180 \x03(\v2\x13.gostats.v1.NetStatR\rnetInterfaces\x12B\n" +
+	"\n" +
+	"tcp_states\x181 \x03(\v2#.gostats.v1.Snapshot.TcpStatesEntryR\ttcpStates\x12/\n" +
+	"\x12net_err_in_per_sec\x18H \x01(\x01H\x16R\x0enetErrInPerSec\x88\x01\x01\x121\n" +
+	"\x13net_err_out_per_sec\x18I \x01(\x01H\x17R\x0fnetErrOutPerSec\x88\x01\x01\x121\n" +
+	"\x13net_drop_in_per_sec\x18J \x01(\x01H\x18R\x0fnetDropInPerSec\x88\x01\x01\x123\n" +
+	"\x14net_drop_out_per_sec\x18K \x01(\x01H\x19R\x10netDropOutPerSec\x88\x01\x01\x12-\n" +
+	"\x05ports\x18L \x01(\v2\x17.gostats.v1.PortSummaryR\x05ports\x12&\n" +
+	"\fctx_switches\x182 \x01(\x04H\x1aR\vctxSwitches\x88\x01\x01\x12#\n" +
+	"\n" +
+	"interrupts\x183 \x01(\x04H\x1bR\n" +
+	"interrupts\x88\x01\x01\x124\n" +
+	"\x14ctx_switches_per_sec\x184 \x01(\x01H\x1cR\x11ctxSwitchesPerSec\x88\x01\x01\x121\n" +
+	"\x12interrupts_per_sec\x185 \x01(\x01H\x1dR\x10interruptsPerSec\x88\x01\x01\x12\x1e\n" +
+	"\bfds_open\x186 \x01(\x04H\x1eR\afdsOpen\x88\x01\x01\x12\x1c\n" +
+	"\afds_max\x187 \x01(\x04H\x1fR\x06fdsMax\x88\x01\x01\x12%\n" +
+	"\ffds_used_pct\x188 \x01(\x01H R\n" +
+	"fdsUsedPct\x88\x01\x01\x12.\n" +
+	"\x06cgroup\x18< \x01(\v2\x16.gostats.v1.CgroupStatR\x06cgroup\x120\n" +
+	"\bsmoothed\x18M \x01(\v2\x14.gostats.v1.SmoothedR\bsmoothed\x12\"\n" +
+	"\n" +
+	"user_count\x18= \x01(\x04H!R\tuserCount\x88\x01\x01\x12-\n" +
+	"\x05users\x18N \x03(\v2\x17.gostats.v1.UserSessionR\x05users\x12\"\n" +
+	"\n" +
+	"proc_count\x18> \x01(\x04H\"R\tprocCount\x88\x01\x01\x12&\n" +
+	"\fthread_count\x18? \x01(\x04H#R\vthreadCount\x88\x01\x01\x12&\n" +
+	"\fzombie_count\x18@ \x01(\x04H$R\vzombieCount\x88\x01\x01\x12:\n" +
+	"\ftemperatures\x18A \x03(\v2\x16.gostats.v1.SensorTempR\ftemperatures\x12,\n" +
+	"\x0fbattery_percent\x18B \x01(\x01H%R\x0ebatteryPercent\x88\x01\x01\x12.\n" +
+	"\x10battery_charging\x18C \x01(\bH&R\x0fbatteryCharging\x88\x01\x01\x122\n" +
+	"\tprocesses\x18D \x03(\v2\x14.gostats.v1.ProcStatR\tprocesses\x128\n" +
+	"\x06errors\x18F \x03(\v2 .gostats.v1.Snapshot.ErrorsEntryR\x06errors\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a<\n" +
+	"\x0eTcpStatesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\x1a9\n" +
+	"\vErrorsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x0e\n" +
+	"\f_cpu_percentB\b\n" +
+	"\x06_load1B\b\n" +
+	"\x06_load5B\t\n" +
+	"\a_load15B\x10\n" +
+	"\x0e_load_per_coreB\x0e\n" +
+	"\f_mem_used_mbB\x0f\n" +
+	"\r_mem_total_mbB\x0f\n" +
+	"\r_mem_used_pctB\x13\n" +
+	"\x11_mem_available_mbB\x10\n" +
+	"\x0e_mem_cached_mbB\x11\n" +
+	"\x0f_mem_buffers_mbB\x0f\n" +
+	"\r_swap_used_mbB\x10\n" +
+	"\x0e_swap_total_mbB\x10\n" +
+	"\x0e_swap_used_pctB\x0f\n" +
+	"\r_net_bytes_inB\x10\n" +
+	"\x0e_net_bytes_outB\x12\n" +
+	"\x10_net_rate_in_bpsB\x13\n" +
+	"\x11_net_rate_out_bpsB\r\n" +
+	"\v_net_err_inB\x0e\n" +
+	"\f_net_err_outB\x0e\n" +
+	"\f_net_drop_inB\x0f\n" +
+	"\r_net_drop_outB\x15\n" +
+	"\x13_net_err_in_per_secB\x16\n" +
+	"\x14_net_err_out_per_secB\x16\n" +
+	"\x14_net_drop_in_per_secB\x17\n" +
+	"\x15_net_drop_out_per_secB\x0f\n" +
+	"\r_ctx_switchesB\r\n" +
+	"\v_interruptsB\x17\n" +
+	"\x15_ctx_switches_per_secB\x15\n" +
+	"\x13_interrupts_per_secB\v\n" +
+	"\t_fds_openB\n" +
+	"\n" +
+	"\b_fds_maxB\x0f\n" +
+	"\r_fds_used_pctB\r\n" +
+	"\v_user_countB\r\n" +
+	"\v_proc_countB\x0f\n" +
+	"\r_thread_countB\x0f\n" +
+	"\r_zombie_countB\x12\n" +
+	"\x10_battery_percentB\x13\n" +
+	"\x11_battery_charging\"\x99\x01\n" +
+	"\tCloudMeta\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x1f\n" +
+	"\vinstance_id\x18\x02 \x01(\tR\n" +
+	"instanceId\x12\x16\n" +
+	"\x06region\x18\x03 \x01(\tR\x06region\x12\x12\n" +
+	"\x04zone\x18\x04 \x01(\tR\x04zone\x12#\n" +
+	"\rinstance_type\x18\x05 \x01(\tR\finstanceType\"\xe1\x01\n" +
+	"\bCPUTimes\x12\x12\n" +
+	"\x04user\x18\x01 \x01(\x01R\x04user\x12\x16\n" +
+	"\x06system\x18\x02 \x01(\x01R\x06system\x12\x12\n" +
+	"\x04idle\x18\x03 \x01(\x01R\x04idle\x12\x1b\n" +
+	"\x06iowait\x18\x04 \x01(\x01H\x00R\x06iowait\x88\x01\x01\x12\x15\n" +
+	"\x03irq\x18\x05 \x01(\x01H\x01R\x03irq\x88\x01\x01\x12\x1d\n" +
+	"\asoftirq\x18\x06 \x01(\x01H\x02R\asoftirq\x88\x01\x01\x12\x19\n" +
+	"\x05steal\x18\a \x01(\x01H\x03R\x05steal\x88\x01\x01B\t\n" +
+	"\a_iowaitB\x06\n" +
+	"\x04_irqB\n" +
+	"\n" +
+	"\b_softirqB\b\n" +
+	"\x06_steal\"\xf2\x01\n" +
+	"\bDiskStat\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x17\n" +
+	"\aused_gb\x18\x02 \x01(\x01R\x06usedGb\x12\x19\n" +
+	"\btotal_gb\x18\x03 \x01(\x01R\atotalGb\x12\x19\n" +
+	"\bused_pct\x18\x04 \x01(\x01R\ausedPct\x12\x1f\n" +
+	"\vinodes_used\x18\x05 \x01(\x04R\n" +
+	"inodesUsed\x12!\n" +
+	"\finodes_total\x18\x06 \x01(\x04R\vinodesTotal\x12+\n" +
+	"\x0finodes_used_pct\x18\a \x01(\x01H\x00R\rinodesUsedPct\x88\x01\x01B\x12\n" +
+	"\x10_inodes_used_pct\"\x86\x03\n" +
+	"\n" +
+	"DiskIOStat\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"read_bytes\x18\x02 \x01(\x04R\treadBytes\x12\x1f\n" +
+	"\vwrite_bytes\x18\x03 \x01(\x04R\n" +
+	"writeBytes\x12\x1d\n" +
+	"\n" +
+	"read_count\x18\x04 \x01(\x04R\treadCount\x12\x1f\n" +
+	"\vwrite_count\x18\x05 \x01(\x04R\n" +
+	"writeCount\x12\x1e\n" +
+	"\bread_bps\x18\x06 \x01(\x01H\x00R\areadBps\x88\x01\x01\x12 \n" +
+	"\twrite_bps\x18\a \x01(\x01H\x01R\bwriteBps\x88\x01\x01\x12,\n" +
+	"\x10read_ops_per_sec\x18\b \x01(\x01H\x02R\rreadOpsPerSec\x88\x01\x01\x12.\n" +
+	"\x11write_ops_per_sec\x18\t \x01(\x01H\x03R\x0ewriteOpsPerSec\x88\x01\x01B\v\n" +
+	"\t_read_bpsB\f\n" +
+	"\n" +
+	"_write_bpsB\x13\n" +
+	"\x11_read_ops_per_secB\x14\n" +
+	"\x12_write_ops_per_sec\"\xf9\x01\n" +
+	"\aNetStat\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
+	"\bbytes_in\x18\x02 \x01(\x04R\abytesIn\x12\x1b\n" +
+	"\tbytes_out\x18\x03 \x01(\x04R\bbytesOut\x12\x1d\n" +
+	"\n" +
+	"packets_in\x18\x04 \x01(\x04R\tpacketsIn\x12\x1f\n" +
+	"\vpackets_out\x18\x05 \x01(\x04R\n" +
+	"packetsOut\x12\x15\n" +
+	"\x06err_in\x18\x06 \x01(\x04R\x05errIn\x12\x17\n" +
+	"\aerr_out\x18\a \x01(\x04R\x06errOut\x12\x17\n" +
+	"\adrop_in\x18\b \x01(\x04R\x06dropIn\x12\x19\n" +
+	"\bdrop_out\x18\t \x01(\x04R\adropOut\"\xad\x02\n" +
+	"\n" +
+	"CgroupStat\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x05R\aversion\x12%\n" +
+	"\fmem_limit_mb\x18\x02 \x01(\x04H\x00R\n" +
+	"memLimitMb\x88\x01\x01\x12\x1e\n" +
+	"\vmem_used_mb\x18\x03 \x01(\x04R\tmemUsedMb\x12%\n" +
+	"\fmem_used_pct\x18\x04 \x01(\x01H\x01R\n" +
+	"memUsedPct\x88\x01\x01\x12+\n" +
+	"\x0fcpu_limit_cores\x18\x05 \x01(\x01H\x02R\rcpuLimitCores\x88\x01\x01\x12$\n" +
+	"\vcpu_percent\x18\x06 \x01(\x01H\x03R\n" +
+	"cpuPercent\x88\x01\x01B\x0f\n" +
+	"\r_mem_limit_mbB\x0f\n" +
+	"\r_mem_used_pctB\x12\n" +
+	"\x10_cpu_limit_coresB\x0e\n" +
+	"\f_cpu_percent\"h\n" +
+	"\n" +
+	"SensorTemp\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x18\n" +
+	"\acurrent\x18\x02 \x01(\x01R\acurrent\x12\x12\n" +
+	"\x04high\x18\x03 \x01(\x01R\x04high\x12\x1a\n" +
+	"\bcritical\x18\x04 \x01(\x01R\bcritical\"\x9e\x02\n" +
+	"\vPortSummary\x126\n" +
+	"\tlistening\x18\x01 \x03(\v2\x18.gostats.v1.ListenSocketR\tlistening\x12^\n" +
+	"\x13established_by_port\x18\x02 \x03(\v2..gostats.v1.PortSummary.EstablishedByPortEntryR\x11establishedByPort\x121\n" +
+	"\x14established_outbound\x18\x03 \x01(\x03R\x13establishedOutbound\x1aD\n" +
+	"\x16EstablishedByPortEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\rR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"6\n" +
+	"\fListenSocket\x12\x12\n" +
+	"\x04addr\x18\x01 \x01(\tR\x04addr\x12\x12\n" +
+	"\x04port\x18\x02 \x01(\rR\x04port\"\x93\x02\n" +
+	"\bSmoothed\x12\x16\n" +
+	"\x06window\x18\x01 \x01(\x05R\x06window\x12$\n" +
+	"\vcpu_percent\x18\x02 \x01(\x01H\x00R\n" +
+	"cpuPercent\x88\x01\x01\x12%\n" +
+	"\fmem_used_pct\x18\x03 \x01(\x01H\x01R\n" +
+	"memUsedPct\x88\x01\x01\x12*\n" +
+	"\x0fnet_rate_in_bps\x18\x04 \x01(\x01H\x02R\fnetRateInBps\x88\x01\x01\x12,\n" +
+	"\x10net_rate_out_bps\x18\x05 \x01(\x01H\x03R\rnetRateOutBps\x88\x01\x01B\x0e\n" +
+	"\f_cpu_percentB\x0f\n" +
+	"\r_mem_used_pctB\x12\n" +
+	"\x10_net_rate_in_bpsB\x13\n" +
+	"\x11_net_rate_out_bps\"Q\n" +
+	"\vUserSession\x12\x12\n" +
+	"\x04user\x18\x01 \x01(\tR\x04user\x12\x1a\n" +
+	"\bterminal\x18\x02 \x01(\tR\bterminal\x12\x12\n" +
+	"\x04host\x18\x03 \x01(\tR\x04host\"h\n" +
+	"\bProcStat\x12\x10\n" +
+	"\x03pid\x18\x01 \x01(\x05R\x03pid\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
+	"\vcpu_percent\x18\x03 \x01(\x01R\n" +
+	"cpuPercent\x12\x15\n" +
+	"\x06mem_mb\x18\x04 \x01(\x01R\x05memMb2F\n" +
+	"\aGostats\x12;\n" +
+	"\x06Stream\x12\x19.gostats.v1.StreamRequest\x1a\x14.gostats.v1.Snapshot0\x01B%Z#github.com/qovert/gostats/gostatspbb\x06proto3"
+
+var (
+	file_gostats_proto_rawDescOnce sync.Once
+	file_gostats_proto_rawDescData []byte
+)
+
+func file_gostats_proto_rawDescGZIP() []byte {
+	file_gostats_proto_rawDescOnce.Do(func() {
+		file_gostats_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_gostats_proto_rawDesc), len(file_gostats_proto_rawDesc)))
+	})
+	return file_gostats_proto_rawDescData
+}
+
+var file_gostats_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_gostats_proto_goTypes = []any{
+	(*StreamRequest)(nil),         // 0: gostats.v1.StreamRequest
+	(*Snapshot)(nil),              // 1: gostats.v1.Snapshot
+	(*CloudMeta)(nil),             // 2: gostats.v1.CloudMeta
+	(*CPUTimes)(nil),              // 3: gostats.v1.CPUTimes
+	(*DiskStat)(nil),              // 4: gostats.v1.DiskStat
+	(*DiskIOStat)(nil),            // 5: gostats.v1.DiskIOStat
+	(*NetStat)(nil),               // 6: gostats.v1.NetStat
+	(*CgroupStat)(nil),            // 7: gostats.v1.CgroupStat
+	(*SensorTemp)(nil),            // 8: gostats.v1.SensorTemp
+	(*PortSummary)(nil),           // 9: gostats.v1.PortSummary
+	(*ListenSocket)(nil),          // 10: gostats.v1.ListenSocket
+	(*Smoothed)(nil),              // 11: gostats.v1.Smoothed
+	(*UserSession)(nil),           // 12: gostats.v1.UserSession
+	(*ProcStat)(nil),              // 13: gostats.v1.ProcStat
+	nil,                           // 14: gostats.v1.Snapshot.LabelsEntry
+	nil,                           // 15: gostats.v1.Snapshot.TcpStatesEntry
+	nil,                           // 16: gostats.v1.Snapshot.ErrorsEntry
+	nil,                           // 17: gostats.v1.PortSummary.EstablishedByPortEntry
+	(*timestamppb.Timestamp)(nil), // 18: google.protobuf.Timestamp
+}
+var file_gostats_proto_depIdxs = []int32{
+	18, // 0: gostats.v1.Snapshot.ts:type_name -> google.protobuf.Timestamp
+	2,  // 1: gostats.v1.Snapshot.cloud:type_name -> gostats.v1.CloudMeta
+	14, // 2: gostats.v1.Snapshot.labels:type_name -> gostats.v1.Snapshot.LabelsEntry
+	3,  // 3: gostats.v1.Snapshot.cpu_times:type_name -> gostats.v1.CPUTimes
+	4,  // 4: gostats.v1.Snapshot.disks:type_name -> gostats.v1.DiskStat
+	5,  // 5: gostats.v1.Snapshot.disk_io:type_name -> gostats.v1.DiskIOStat
+	6,  // 6: gostats.v1.Snapshot.net_interfaces:type_name -> gostats.v1.NetStat
+	15, // 7: gostats.v1.Snapshot.tcp_states:type_name -> gostats.v1.Snapshot.TcpStatesEntry
+	9,  // 8: gostats.v1.Snapshot.ports:type_name -> gostats.v1.PortSummary
+	7,  // 9: gostats.v1.Snapshot.cgroup:type_name -> gostats.v1.CgroupStat
+	11, // 10: gostats.v1.Snapshot.smoothed:type_name -> gostats.v1.Smoothed
+	12, // 11: gostats.v1.Snapshot.users:type_name -> gostats.v1.UserSession
+	8,  // 12: gostats.v1.Snapshot.temperatures:type_name -> gostats.v1.SensorTemp
+	13, // 13: gostats.v1.Snapshot.processes:type_name -> gostats.v1.ProcStat
+	16, // 14: gostats.v1.Snapshot.errors:type_name -> gostats.v1.Snapshot.ErrorsEntry
+	10, // 15: gostats.v1.PortSummary.listening:type_name -> gostats.v1.ListenSocket
+	17, // 16: gostats.v1.PortSummary.established_by_port:type_name -> gostats.v1.PortSummary.EstablishedByPortEntry
+	0,  // 17: gostats.v1.Gostats.Stream:input_type -> gostats.v1.StreamRequest
+	1,  // 18: gostats.v1.Gostats.Stream:output_type -> gostats.v1.Snapshot
+	18, // [18:19] is the sub-list for method output_type
+	17, // [17:18] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_gostats_proto_init() }
+func file_gostats_proto_init() {
+	if File_gostats_proto != nil {
+		return
+	}
+	file_gostats_proto_msgTypes[1].OneofWrappers = []any{}
+	file_gostats_proto_msgTypes[3].OneofWrappers = []any{}
+	file_gostats_proto_msgTypes[4].OneofWrappers = []any{}
+	file_gostats_proto_msgTypes[5].OneofWrappers = []any{}
+	file_gostats_proto_msgTypes[7].OneofWrappers = []any{}
+	file_gostats_proto_msgTypes[11].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_gostats_proto_rawDesc), len(file_gostats_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_gostats_proto_goTypes,
+		DependencyIndexes: file_gostats_proto_depIdxs,
+		MessageInfos:      file_gostats_proto_msgTypes,
+	}.Build()
+	File_gostats_proto = out.File
+	file_gostats_proto_goTypes = nil
+	file_gostats_proto_depIdxs = nil
+}
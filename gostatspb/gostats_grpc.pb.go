@@ -0,0 +1,133 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: gostats.proto
+
+// Package gostats.v1 carries a fixed subset of the JSON snapshot written by
+// `gostats collect`: the fields defined below, under the same names as their
+// JSON keys. The --units display strings and the deprecated mem_free_pct are
+// left out, as is anything added to the JSON snapshot that has no field here;
+// use `collect --json` when you need the full snapshot. Optional fields are
+// only set when the corresponding metric was collected.
+
+package gostatspb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Gostats_Stream_FullMethodName = "/gostats.v1.Gostats/Stream"
+)
+
+// GostatsClient is the client API for Gostats service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GostatsClient interface {
+	// Stream sends a snapshot every --interval until the client goes away.
+	Stream(ctx context.Context, in *StreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Snapshot], error)
+}
+
+type gostatsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGostatsClient(cc grpc.ClientConnInterface) GostatsClient {
+	return &gostatsClient{cc}
+}
+
+func (c *gostatsClient) Stream(ctx context.Context, in *StreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Snapshot], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Gostats_ServiceDesc.Streams[0], Gostats_Stream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamRequest, Snapshot]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Gostats_StreamClient = grpc.ServerStreamingClient[Snapshot]
+
+// GostatsServer is the server API for Gostats service.
+// All implementations must embed UnimplementedGostatsServer
+// for forward compatibility.
+type GostatsServer interface {
+	// Stream sends a snapshot every --interval until the client goes away.
+	Stream(*StreamRequest, grpc.ServerStreamingServer[Snapshot]) error
+	mustEmbedUnimplementedGostatsServer()
+}
+
+// UnimplementedGostatsServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGostatsServer struct{}
+
+func (UnimplementedGostatsServer) Stream(*StreamRequest, grpc.ServerStreamingServer[Snapshot]) error {
+	return status.Errorf(codes.Unimplemented, "method Stream not implemented")
+}
+func (UnimplementedGostatsServer) mustEmbedUnimplementedGostatsServer() {}
+func (UnimplementedGostatsServer) testEmbeddedByValue()                 {}
+
+// UnsafeGostatsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GostatsServer will
+// result in compilation errors.
+type UnsafeGostatsServer interface {
+	mustEmbedUnimplementedGostatsServer()
+}
+
+func RegisterGostatsServer(s grpc.ServiceRegistrar, srv GostatsServer) {
+	// If the following call pancis, it indicates UnimplementedGostatsServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Gostats_ServiceDesc, srv)
+}
+
+func _Gostats_Stream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GostatsServer).Stream(m, &grpc.GenericServerStream[StreamRequest, Snapshot]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Gostats_StreamServer = grpc.ServerStreamingServer[Snapshot]
+
+// Gostats_ServiceDesc is the grpc.ServiceDesc for Gostats service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Gostats_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gostats.v1.Gostats",
+	HandlerType: (*GostatsServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Stream",
+			Handler:       _Gostats_Stream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "gostats.proto",
+}